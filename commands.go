@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/0ch1r/terraform-provider-auditlogfilter/internal/provider"
+)
+
+const commandUsage = `Usage: terraform-provider-auditlogfilters [-debug] [command]
+
+Without a command the binary runs as a Terraform provider plugin.
+
+Commands:
+  export    Generate Terraform configuration and import blocks from a server
+  schema    Print the JSON Schema for audit log filter definitions
+  validate  Validate audit log filter definition files
+`
+
+// runCommand runs a command-line subcommand and returns the process exit code.
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	switch args[0] {
+	case "export":
+		return runExportCommand(args[1:], stdout, stderr)
+	case "schema":
+		return runSchemaCommand(args[1:], stdout, stderr)
+	case "validate":
+		return runValidateCommand(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		_, _ = fmt.Fprint(stdout, commandUsage)
+		return 0
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], commandUsage)
+		return 2
+	}
+}
+
+func runSchemaCommand(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		_, _ = fmt.Fprintf(stderr, "schema takes no arguments\n")
+		return 2
+	}
+
+	document, err := provider.DefinitionJSONSchema()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to generate schema: %v\n", err)
+		return 1
+	}
+
+	_, _ = fmt.Fprintln(stdout, string(document))
+	return 0
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/0ch1r/terraform-provider-auditlogfilter/internal/provider"
+)
+
+// exportFileOrder is the order in which exported files are printed to stdout.
+var exportFileOrder = []string{
+	provider.ExportFiltersFile,
+	provider.ExportAssignmentsFile,
+	provider.ExportImportsFile,
+}
+
+func runExportCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("out", "", "directory to write the .tf files to (default: print to stdout)")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: terraform-provider-auditlogfilters export [-out DIR]\n\n"+
+			"Generates Terraform configuration and import blocks for the audit log filters and user assignments\n"+
+			"on the server configured by the MYSQL_* environment variables.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return 2
+	}
+
+	files, err := provider.ExportConfiguration(context.Background())
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "export failed: %v\n", err)
+		return 1
+	}
+
+	return writeExportedFiles(files, *out, stdout, stderr)
+}
+
+func writeExportedFiles(files map[string][]byte, out string, stdout, stderr io.Writer) int {
+	if out == "" {
+		for _, name := range exportFileOrder {
+			_, _ = fmt.Fprintf(stdout, "# %s\n%s\n", name, files[name])
+		}
+		return 0
+	}
+
+	if err := os.MkdirAll(out, 0o750); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+	for _, name := range exportFileOrder {
+		if err := os.WriteFile(filepath.Join(out, name), files[name], 0o600); err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	return 0
+}
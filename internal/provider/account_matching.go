@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mysqlAccount is a row of mysql.user.
+type mysqlAccount struct {
+	username   string
+	userhost   string
+	privileged bool
+	locked     bool
+}
+
+// listMySQLAccounts returns every account on the server ordered by user and
+// host. Accounts holding SUPER, GRANT OPTION or CREATE USER are privileged.
+func listMySQLAccounts(ctx context.Context, db *mysqlClient) (accounts []mysqlAccount, err error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT User, Host, Super_priv = 'Y' OR Grant_priv = 'Y' OR Create_user_priv = 'Y', account_locked = 'Y' "+
+			"FROM mysql.user ORDER BY User, Host")
+	if err != nil {
+		return nil, fmt.Errorf("query accounts: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close account rows: %w", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var account mysqlAccount
+		if err := rows.Scan(&account.username, &account.userhost, &account.privileged, &account.locked); err != nil {
+			return nil, fmt.Errorf("scan account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// authenticatedAccount returns the account the server authenticates a client
+// connecting as username from clientHost, or false when no account matches.
+// Accounts are tried in the server's precedence order: IPv4 hosts with a
+// netmask first (wider netmasks later), then every other host, including
+// plain IP addresses, by specificity, and for equal hosts named users before
+// the anonymous user.
+func authenticatedAccount(accounts []mysqlAccount, username, clientHost string) (mysqlAccount, bool) {
+	ordered := make([]mysqlAccount, len(accounts))
+	copy(ordered, accounts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return accountPrecedes(ordered[i], ordered[j])
+	})
+
+	for _, account := range ordered {
+		if account.username != "" && account.username != username {
+			continue
+		}
+		if hostPatternMatches(account.userhost, clientHost) {
+			return account, true
+		}
+	}
+
+	return mysqlAccount{}, false
+}
+
+// accountPrecedes mirrors the server's ACL_compare ordering. The server only
+// treats ip/mask hosts as IP entries; a plain address has no netmask and is
+// sorted with the host names.
+func accountPrecedes(a, b mysqlAccount) bool {
+	_, aMask, aHasMask, aOK := parseAccountIPv4(a.userhost)
+	_, bMask, bHasMask, bOK := parseAccountIPv4(b.userhost)
+	aNetmask := aOK && aHasMask
+	bNetmask := bOK && bHasMask
+
+	switch {
+	case aNetmask && bNetmask:
+		if aMask != bMask {
+			return aMask > bMask
+		}
+	case aNetmask:
+		return true
+	case bNetmask:
+		return false
+	}
+
+	return accountSortKey(a) > accountSortKey(b)
+}
+
+// accountSortKey mirrors the server's get_sort(): each of host and user
+// scores 128 when it has no wildcard, otherwise the position of its first
+// wildcard, so longer literal prefixes sort first. A lone '%' scores lowest.
+func accountSortKey(account mysqlAccount) uint32 {
+	return wildcardSortValue(account.userhost)<<8 + wildcardSortValue(account.username)
+}
+
+func wildcardSortValue(s string) uint32 {
+	var chars uint32
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\\' && i+1 < len(s) {
+			i++
+		} else if c == '%' || c == '_' {
+			wildPos := uint32(i) + 1
+			if !(wildPos == 1 && c == '%' && i+1 == len(s)) {
+				wildPos++
+			}
+			return min(wildPos, 127)
+		}
+		chars = 128
+	}
+	return chars
+}
+
+// parseAccountIPv4 recognises hosts the server stores as a literal IPv4
+// address, optionally with a netmask or CIDR prefix.
+func parseAccountIPv4(host string) (ip, mask uint32, hasMask, ok bool) {
+	address, maskText, hasMask := strings.Cut(host, "/")
+	parsed := net.ParseIP(address)
+	if parsed == nil || parsed.To4() == nil || strings.Contains(address, ":") {
+		return 0, 0, false, false
+	}
+
+	ipMask := net.CIDRMask(32, 32)
+	if hasMask {
+		if validateHostNetmask(address, maskText) != nil {
+			return 0, 0, false, false
+		}
+		if maskIP := net.ParseIP(maskText); maskIP != nil {
+			ipMask = net.IPMask(maskIP.To4())
+		} else {
+			prefix, _ := strconv.Atoi(maskText)
+			ipMask = net.CIDRMask(prefix, 32)
+		}
+	}
+
+	return ipv4Uint(parsed.To4()), ipv4Uint(net.IP(ipMask)), hasMask, true
+}
+
+func ipv4Uint(ip net.IP) uint32 {
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+// hostPatternMatches reports whether an account host pattern matches a
+// client host name or IP address. An empty pattern matches any host.
+func hostPatternMatches(pattern, clientHost string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	if ip, mask, _, ok := parseAccountIPv4(pattern); ok {
+		client := net.ParseIP(clientHost)
+		if client == nil || client.To4() == nil {
+			return false
+		}
+		return ipv4Uint(client.To4())&mask == ip
+	}
+
+	return likeMatch(normalizeHostPattern(pattern), normalizeHostPattern(clientHost))
+}
+
+// likeMatch matches s against a SQL LIKE pattern with '%', '_' and backslash
+// escapes.
+func likeMatch(pattern, s string) bool {
+	if pattern == "" {
+		return s == ""
+	}
+
+	switch pattern[0] {
+	case '%':
+		for i := 0; i <= len(s); i++ {
+			if likeMatch(pattern[1:], s[i:]) {
+				return true
+			}
+		}
+		return false
+	case '_':
+		return s != "" && likeMatch(pattern[1:], s[1:])
+	case '\\':
+		if len(pattern) > 1 {
+			return s != "" && s[0] == pattern[1] && likeMatch(pattern[2:], s[1:])
+		}
+	}
+
+	return s != "" && s[0] == pattern[0] && likeMatch(pattern[1:], s[1:])
+}
@@ -0,0 +1,106 @@
+package provider
+
+import "testing"
+
+func TestAuthenticatedAccount(t *testing.T) {
+	t.Parallel()
+
+	accounts := []mysqlAccount{
+		{username: "app", userhost: "%"},
+		{username: "app", userhost: "%.example.com"},
+		{username: "app", userhost: "db1.example.com"},
+		{username: "app", userhost: "10.1.%"},
+		{username: "app", userhost: "10.1.2.0/255.255.255.0"},
+		{username: "app", userhost: "10.1.0.0/16"},
+		{username: "app", userhost: "10.1.2.3"},
+		{username: "", userhost: "localhost"},
+		{username: "admin", userhost: "%"},
+	}
+
+	cases := []struct {
+		name     string
+		user     string
+		host     string
+		wantHost string
+		wantUser string
+		wantOK   bool
+	}{
+		{name: "netmask before literal ip", user: "app", host: "10.1.2.3", wantUser: "app", wantHost: "10.1.2.0/255.255.255.0", wantOK: true},
+		{name: "narrower netmask first", user: "app", host: "10.1.2.4", wantUser: "app", wantHost: "10.1.2.0/255.255.255.0", wantOK: true},
+		{name: "wider netmask", user: "app", host: "10.1.9.9", wantUser: "app", wantHost: "10.1.0.0/16", wantOK: true},
+		{name: "literal host name", user: "app", host: "DB1.example.com", wantUser: "app", wantHost: "db1.example.com", wantOK: true},
+		{name: "domain wildcard", user: "app", host: "db2.example.com", wantUser: "app", wantHost: "%.example.com", wantOK: true},
+		{name: "any host", user: "app", host: "elsewhere.net", wantUser: "app", wantHost: "%", wantOK: true},
+		{name: "anonymous localhost beats named any host", user: "admin", host: "localhost", wantUser: "", wantHost: "localhost", wantOK: true},
+		{name: "no account", user: "nobody", host: "elsewhere.net", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			account, ok := authenticatedAccount(accounts, tc.user, tc.host)
+			if ok != tc.wantOK {
+				t.Fatalf("authenticatedAccount(%q, %q) ok = %t, want %t", tc.user, tc.host, ok, tc.wantOK)
+			}
+			if ok && (account.username != tc.wantUser || account.userhost != tc.wantHost) {
+				t.Fatalf("authenticatedAccount(%q, %q) = %q@%q, want %q@%q",
+					tc.user, tc.host, account.username, account.userhost, tc.wantUser, tc.wantHost)
+			}
+		})
+	}
+}
+
+func TestHostPatternMatches(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern string
+		host    string
+		want    bool
+	}{
+		{pattern: "", host: "anything", want: true},
+		{pattern: "%", host: "anything", want: true},
+		{pattern: "%.example.com", host: "a.example.com", want: true},
+		{pattern: "%.example.com", host: "example.com", want: false},
+		{pattern: "db_.example.com", host: "db1.example.com", want: true},
+		{pattern: "db_.example.com", host: "db10.example.com", want: false},
+		{pattern: "LOCALHOST", host: "localhost", want: true},
+		{pattern: "10.0.0.%", host: "10.0.0.7", want: true},
+		{pattern: "10.0.0.0/255.255.255.0", host: "10.0.0.7", want: true},
+		{pattern: "10.0.0.0/24", host: "10.0.1.7", want: false},
+		{pattern: "10.0.0.0/24", host: "db.example.com", want: false},
+		{pattern: "10.0.0.1", host: "10.0.0.1", want: true},
+		{pattern: `db\_1`, host: "db_1", want: true},
+		{pattern: `db\_1`, host: "dbx1", want: false},
+	}
+
+	for _, tc := range cases {
+		if got := hostPatternMatches(tc.pattern, tc.host); got != tc.want {
+			t.Fatalf("hostPatternMatches(%q, %q) = %t, want %t", tc.pattern, tc.host, got, tc.want)
+		}
+	}
+}
+
+func TestWildcardSortValue(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value string
+		want  uint32
+	}{
+		{value: "", want: 0},
+		{value: "%", want: 1},
+		{value: "localhost", want: 128},
+		{value: "%.example.com", want: 2},
+		{value: "10.1.%", want: 7},
+		{value: `a\%b`, want: 128},
+	}
+
+	for _, tc := range cases {
+		if got := wildcardSortValue(tc.value); got != tc.want {
+			t.Fatalf("wildcardSortValue(%q) = %d, want %d", tc.value, got, tc.want)
+		}
+	}
+}
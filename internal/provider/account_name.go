@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultAccount is the user specification of the default assignment that
+// applies to accounts without an explicit one.
+const defaultAccount = "%"
+
+// formatAccountName quotes an account as 'user'@'host', following the MySQL
+// account-name syntax. Quotes and backslashes inside either part are escaped
+// so that parseAccountName returns the original parts.
+func formatAccountName(username, userhost string) string {
+	return quoteAccountPart(username) + "@" + quoteAccountPart(userhost)
+}
+
+func quoteAccountPart(part string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(part)
+	return "'" + escaped + "'"
+}
+
+// buildUserSpec returns the user specification passed to the
+// audit_log_filter_set_user() and audit_log_filter_remove_user() functions.
+func buildUserSpec(username, userhost string) string {
+	if username == defaultAccount {
+		return defaultAccount
+	}
+	if userhost == "" {
+		userhost = "%"
+	}
+	return formatAccountName(username, userhost)
+}
+
+// parseAccountName parses an account name in any form MySQL accepts:
+// 'user'@'host', "user"@"host", `user`@`host`, user@host or a bare user name,
+// which matches any host. Unquoted names are split at the last '@' because
+// host names cannot contain one. The anonymous account has an empty quoted
+// user name before the '@'.
+// The default specification "%" returns an empty host.
+func parseAccountName(spec string) (username, userhost string, err error) {
+	if spec == "" {
+		return "", "", errors.New("account name must not be empty")
+	}
+	if spec == defaultAccount {
+		return defaultAccount, "", nil
+	}
+
+	if !isAccountQuote(spec[0]) {
+		at := strings.LastIndex(spec, "@")
+		if at < 0 {
+			return spec, "%", nil
+		}
+		username = spec[:at]
+		if username == "" {
+			return "", "", fmt.Errorf("account name %q has an empty unquoted user name; write the anonymous user as ''@'host'", spec)
+		}
+		userhost, err = parseAccountHost(spec, spec[at+1:])
+		return username, userhost, err
+	}
+
+	username, rest, err := unquoteAccountPart(spec)
+	if err != nil {
+		return "", "", fmt.Errorf("account name %q: %w", spec, err)
+	}
+	if rest == "" {
+		return username, "%", nil
+	}
+	if rest[0] != '@' {
+		return "", "", fmt.Errorf("account name %q: expected '@' after the quoted user name", spec)
+	}
+
+	userhost, err = parseAccountHost(spec, rest[1:])
+	return username, userhost, err
+}
+
+func parseAccountHost(spec, host string) (string, error) {
+	if host == "" || !isAccountQuote(host[0]) {
+		if strings.ContainsAny(host, "'\"`") {
+			return "", fmt.Errorf("account name %q: unquoted host contains a quote character", spec)
+		}
+		return host, nil
+	}
+
+	userhost, rest, err := unquoteAccountPart(host)
+	if err != nil {
+		return "", fmt.Errorf("account name %q: %w", spec, err)
+	}
+	if rest != "" {
+		return "", fmt.Errorf("account name %q: unexpected %q after the quoted host", spec, rest)
+	}
+	return userhost, nil
+}
+
+func isAccountQuote(c byte) bool {
+	return c == '\'' || c == '"' || c == '`'
+}
+
+// unquoteAccountPart reads a quoted identifier or string from the start of s
+// and returns its value and the remaining input. A doubled quote character
+// stands for itself; inside ' and " quotes a backslash escapes the next
+// character.
+func unquoteAccountPart(s string) (value, rest string, err error) {
+	quote := s[0]
+	var sb strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && quote != '`':
+			if i+1 == len(s) {
+				return "", "", errors.New("unterminated escape sequence")
+			}
+			i++
+			sb.WriteByte(s[i])
+		case c == quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				sb.WriteByte(quote)
+				continue
+			}
+			return sb.String(), s[i+1:], nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("missing closing %c quote", quote)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import "testing"
+
+func TestAccountNameRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		username string
+		userhost string
+		want     string
+	}{
+		{name: "plain", username: "app", userhost: "localhost", want: `'app'@'localhost'`},
+		{name: "wildcard host", username: "app", userhost: "%", want: `'app'@'%'`},
+		{name: "at sign in user", username: "svc@corp", userhost: "10.0.0.%", want: `'svc@corp'@'10.0.0.%'`},
+		{name: "single quote", username: "o'brien", userhost: "%", want: `'o''brien'@'%'`},
+		{name: "double quote", username: `say"hi`, userhost: "%", want: `'say"hi'@'%'`},
+		{name: "backslash", username: `dom\user`, userhost: "%", want: `'dom\\user'@'%'`},
+		{name: "anonymous", username: "", userhost: "localhost", want: `''@'localhost'`},
+		{name: "empty host", username: "%", userhost: "", want: `'%'@''`},
+		{name: "unicode", username: "пользователь", userhost: "%.example.com", want: `'пользователь'@'%.example.com'`},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			formatted := formatAccountName(tc.username, tc.userhost)
+			if formatted != tc.want {
+				t.Fatalf("formatAccountName(%q, %q) = %q, want %q", tc.username, tc.userhost, formatted, tc.want)
+			}
+
+			username, userhost, err := parseAccountName(formatted)
+			if err != nil {
+				t.Fatalf("parseAccountName(%q) error = %v", formatted, err)
+			}
+			if username != tc.username || userhost != tc.userhost {
+				t.Fatalf("parseAccountName(%q) = %q, %q; want %q, %q", formatted, username, userhost, tc.username, tc.userhost)
+			}
+		})
+	}
+}
+
+func TestParseAccountName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		spec     string
+		username string
+		userhost string
+	}{
+		{spec: "%", username: "%", userhost: ""},
+		{spec: "app", username: "app", userhost: "%"},
+		{spec: "app@localhost", username: "app", userhost: "localhost"},
+		{spec: "svc@corp@%", username: "svc@corp", userhost: "%"},
+		{spec: "'app'", username: "app", userhost: "%"},
+		{spec: "'app'@localhost", username: "app", userhost: "localhost"},
+		{spec: `"app"@"10.%"`, username: "app", userhost: "10.%"},
+		{spec: "`app`@`%`", username: "app", userhost: "%"},
+		{spec: "`we``ird`@'%'", username: "we`ird", userhost: "%"},
+		{spec: `'o\'brien'@'%'`, username: "o'brien", userhost: "%"},
+		{spec: "''@''", username: "", userhost: ""},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.spec, func(t *testing.T) {
+			t.Parallel()
+
+			username, userhost, err := parseAccountName(tc.spec)
+			if err != nil {
+				t.Fatalf("parseAccountName(%q) error = %v", tc.spec, err)
+			}
+			if username != tc.username || userhost != tc.userhost {
+				t.Fatalf("parseAccountName(%q) = %q, %q; want %q, %q", tc.spec, username, userhost, tc.username, tc.userhost)
+			}
+		})
+	}
+}
+
+func TestParseAccountNameErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"",
+		"@localhost",
+		"'app",
+		"'app'localhost",
+		"'app'@'localhost",
+		"'app'@'localhost'x",
+		"app@local'host",
+		`'app\`,
+	} {
+		spec := spec
+		t.Run(spec, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, err := parseAccountName(spec); err == nil {
+				t.Fatalf("parseAccountName(%q) expected error", spec)
+			}
+		})
+	}
+}
+
+func TestBuildUserSpec(t *testing.T) {
+	t.Parallel()
+
+	if got := buildUserSpec("%", "%"); got != "%" {
+		t.Fatalf("buildUserSpec default = %q, want %%", got)
+	}
+	if got := buildUserSpec("app", ""); got != `'app'@'%'` {
+		t.Fatalf("buildUserSpec empty host = %q", got)
+	}
+	if got := buildUserSpec("svc@corp", "%"); got != `'svc@corp'@'%'` {
+		t.Fatalf("buildUserSpec at sign = %q", got)
+	}
+}
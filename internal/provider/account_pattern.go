@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Pattern types accepted by the pattern assignment resource.
+const (
+	accountPatternLike  = "like"
+	accountPatternRegex = "regex"
+)
+
+// accountPattern selects accounts from mysql.user by user name and host.
+// LIKE patterns use '%', '_' and backslash escapes; regular expressions use
+// RE2 syntax and must match the whole name. An empty userhost matches any
+// host. Hosts are compared case-insensitively, as the server lowercases them.
+type accountPattern struct {
+	kind     string
+	username string
+	userhost string
+}
+
+// matcher compiles the pattern into a predicate over accounts.
+func (p accountPattern) matcher() (func(mysqlAccount) bool, error) {
+	switch p.kind {
+	case accountPatternLike:
+		return func(account mysqlAccount) bool {
+			if !likeMatch(p.username, account.username) {
+				return false
+			}
+			return p.userhost == "" || likeMatch(normalizeHostPattern(p.userhost), normalizeHostPattern(account.userhost))
+		}, nil
+	case accountPatternRegex:
+		username, err := regexp.Compile(`^(?:` + p.username + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid username regular expression: %w", err)
+		}
+		var userhost *regexp.Regexp
+		if p.userhost != "" {
+			userhost, err = regexp.Compile(`(?i)^(?:` + p.userhost + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("invalid userhost regular expression: %w", err)
+			}
+		}
+		return func(account mysqlAccount) bool {
+			if !username.MatchString(account.username) {
+				return false
+			}
+			return userhost == nil || userhost.MatchString(account.userhost)
+		}, nil
+	default:
+		return nil, fmt.Errorf("pattern type must be %q or %q, got %q", accountPatternLike, accountPatternRegex, p.kind)
+	}
+}
+
+// matchingAccounts returns the accounts the pattern selects, in their
+// original order.
+func matchingAccounts(accounts []mysqlAccount, pattern accountPattern) ([]mysqlAccount, error) {
+	matches, err := pattern.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []mysqlAccount
+	for _, account := range accounts {
+		if matches(account) {
+			result = append(result, account)
+		}
+	}
+	return result, nil
+}
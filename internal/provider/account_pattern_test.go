@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchingAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := []mysqlAccount{
+		{username: "app", userhost: "%"},
+		{username: "svc_billing", userhost: "10.0.0.%"},
+		{username: "svc_billing", userhost: "localhost"},
+		{username: "svc_search", userhost: "%"},
+		{username: "svcadmin", userhost: "%"},
+	}
+
+	cases := []struct {
+		name    string
+		pattern accountPattern
+		want    []mysqlAccount
+		wantErr bool
+	}{
+		{
+			name:    "like with escaped underscore",
+			pattern: accountPattern{kind: accountPatternLike, username: `svc\_%`},
+			want: []mysqlAccount{
+				{username: "svc_billing", userhost: "10.0.0.%"},
+				{username: "svc_billing", userhost: "localhost"},
+				{username: "svc_search", userhost: "%"},
+			},
+		},
+		{
+			name:    "like underscore is a wildcard",
+			pattern: accountPattern{kind: accountPatternLike, username: "svc_%"},
+			want: []mysqlAccount{
+				{username: "svc_billing", userhost: "10.0.0.%"},
+				{username: "svc_billing", userhost: "localhost"},
+				{username: "svc_search", userhost: "%"},
+				{username: "svcadmin", userhost: "%"},
+			},
+		},
+		{
+			name:    "like host compared case-insensitively",
+			pattern: accountPattern{kind: accountPatternLike, username: "svc%", userhost: "LOCAL%"},
+			want:    []mysqlAccount{{username: "svc_billing", userhost: "localhost"}},
+		},
+		{
+			name:    "regex matches the whole name",
+			pattern: accountPattern{kind: accountPatternRegex, username: "svc_[a-z]+", userhost: `10\.0\.0\.%`},
+			want:    []mysqlAccount{{username: "svc_billing", userhost: "10.0.0.%"}},
+		},
+		{
+			name:    "regex is anchored",
+			pattern: accountPattern{kind: accountPatternRegex, username: "svc"},
+			want:    nil,
+		},
+		{
+			name:    "invalid regex",
+			pattern: accountPattern{kind: accountPatternRegex, username: "svc_("},
+			wantErr: true,
+		},
+		{
+			name:    "unknown pattern type",
+			pattern: accountPattern{kind: "glob", username: "svc_*"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := matchingAccounts(accounts, tc.pattern)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchingAccounts() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("matchingAccounts() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
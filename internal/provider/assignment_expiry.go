@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// expiryEventPrefix starts the name of every event the provider creates for
+// server-side expiry.
+const expiryEventPrefix = "auditlogfilter_expiry_"
+
+// rfc3339Validator checks that a string is an RFC 3339 timestamp.
+type rfc3339Validator struct{}
+
+var _ validator.String = rfc3339Validator{}
+
+func (v rfc3339Validator) Description(context.Context) string {
+	return "must be an RFC 3339 timestamp such as 2026-10-18T18:00:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			"Expected an RFC 3339 timestamp such as 2026-10-18T18:00:00Z: "+err.Error(),
+		)
+	}
+}
+
+// assignmentExpired reports whether expiresAt is set and not after now.
+// Unparseable values never expire; the validator rejects them.
+func assignmentExpired(expiresAt types.String, now time.Time) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	if err != nil {
+		return false
+	}
+	return !now.Before(expiry)
+}
+
+// activeFilterName returns the filter an assignment should have at now:
+// filterName until it expires, then revertTo, or null when the expired
+// assignment is removed.
+func activeFilterName(filterName, revertTo, expiresAt types.String, now time.Time) types.String {
+	if expiresAt.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if !assignmentExpired(expiresAt, now) {
+		return filterName
+	}
+	return revertTo
+}
+
+// expiryEventName derives a stable event name from the account, which may
+// contain characters that are awkward in identifiers.
+func expiryEventName(username, userhost string) string {
+	sum := sha256.Sum256([]byte(formatAccountName(username, userhost)))
+	return expiryEventPrefix + hex.EncodeToString(sum[:8])
+}
+
+// expiryEventStatement returns the CREATE EVENT statement that reverts an
+// assignment to revertTo at expiresAt, or removes it when revertTo is empty.
+// The event drops itself once it has run. The timestamp is passed through
+// FROM_UNIXTIME so that it is interpreted in the session time zone, as the
+// event schedule is.
+func expiryEventStatement(name, username, userhost, revertTo string, expiresAt time.Time) string {
+	userSpec := sqlStringLiteral(buildUserSpec(username, userhost))
+
+	action := fmt.Sprintf("audit_log_filter_remove_user(%s)", userSpec)
+	if revertTo != "" {
+		action = fmt.Sprintf("audit_log_filter_set_user(%s, %s)", userSpec, sqlStringLiteral(revertTo))
+	}
+
+	return fmt.Sprintf("CREATE EVENT %s ON SCHEDULE AT FROM_UNIXTIME(%d) ON COMPLETION NOT PRESERVE "+
+		"COMMENT 'Managed by Terraform: audit log filter assignment expiry' DO SET @auditlogfilter_expiry = %s",
+		quoteIdentifier(name), expiresAt.Unix(), action)
+}
+
+// createExpiryEvent creates the expiry event of an assignment in the
+// connection's default database and returns its qualified name, schema.name.
+func createExpiryEvent(ctx context.Context, db *mysqlClient, username, userhost, revertTo string, expiresAt time.Time) (string, error) {
+	var schemaName sql.NullString
+	if err := db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&schemaName); err != nil {
+		return "", fmt.Errorf("determine the event schema: %w", err)
+	}
+	if !schemaName.Valid {
+		return "", fmt.Errorf("the provider connection has no default database to create the expiry event in")
+	}
+
+	name := expiryEventName(username, userhost)
+	if _, err := db.ExecContext(ctx, "DROP EVENT IF EXISTS "+quoteIdentifier(name)); err != nil {
+		return "", fmt.Errorf("drop existing expiry event: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, expiryEventStatement(name, username, userhost, revertTo, expiresAt)); err != nil {
+		return "", fmt.Errorf("create expiry event: %w", err)
+	}
+
+	return schemaName.String + "." + name, nil
+}
+
+// dropExpiryEvent drops an event created by createExpiryEvent, if it still
+// exists.
+func dropExpiryEvent(ctx context.Context, db *mysqlClient, qualifiedName string) error {
+	schemaName, name := splitEventName(qualifiedName)
+	if _, err := db.ExecContext(ctx, "DROP EVENT IF EXISTS "+quoteIdentifier(schemaName)+"."+quoteIdentifier(name)); err != nil {
+		return fmt.Errorf("drop expiry event: %w", err)
+	}
+	return nil
+}
+
+// expiryEventExists reports whether an event created by createExpiryEvent is
+// still scheduled.
+func expiryEventExists(ctx context.Context, db *mysqlClient, qualifiedName string) (bool, error) {
+	schemaName, name := splitEventName(qualifiedName)
+	var count int
+	err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.EVENTS WHERE EVENT_SCHEMA = ? AND EVENT_NAME = ?",
+		schemaName, name,
+	).Scan(&count)
+	return count > 0, err
+}
+
+// splitEventName splits schema.name at the last dot; generated event names
+// never contain one.
+func splitEventName(qualifiedName string) (string, string) {
+	i := strings.LastIndex(qualifiedName, ".")
+	if i < 0 {
+		return "", qualifiedName
+	}
+	return qualifiedName[:i], qualifiedName[i+1:]
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// sqlStringLiteral quotes s as a single-quoted SQL string literal.
+func sqlStringLiteral(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(s) + "'"
+}
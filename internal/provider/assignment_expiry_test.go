@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestActiveFilterName(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		revertTo  types.String
+		expiresAt types.String
+		want      types.String
+	}{
+		{
+			name:      "no expiry",
+			revertTo:  types.StringNull(),
+			expiresAt: types.StringNull(),
+			want:      types.StringValue("verbose"),
+		},
+		{
+			name:      "not yet expired",
+			revertTo:  types.StringValue("baseline"),
+			expiresAt: types.StringValue("2026-10-18T14:00:00+01:00"),
+			want:      types.StringValue("verbose"),
+		},
+		{
+			name:      "expired with revert",
+			revertTo:  types.StringValue("baseline"),
+			expiresAt: types.StringValue("2026-10-18T12:00:00Z"),
+			want:      types.StringValue("baseline"),
+		},
+		{
+			name:      "expired without revert",
+			revertTo:  types.StringNull(),
+			expiresAt: types.StringValue("2026-10-17T00:00:00Z"),
+			want:      types.StringNull(),
+		},
+		{
+			name:      "unknown expiry",
+			revertTo:  types.StringNull(),
+			expiresAt: types.StringUnknown(),
+			want:      types.StringUnknown(),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := activeFilterName(types.StringValue("verbose"), tc.revertTo, tc.expiresAt, now)
+			if !got.Equal(tc.want) {
+				t.Fatalf("activeFilterName() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpiryEventStatement(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2026, 10, 18, 18, 0, 0, 0, time.UTC)
+	name := expiryEventName("o'brien", "%")
+
+	if !strings.HasPrefix(name, expiryEventPrefix) || name != expiryEventName("o'brien", "%") || name == expiryEventName("o'brien", "localhost") {
+		t.Fatalf("unexpected event name %q", name)
+	}
+
+	revert := expiryEventStatement(name, "o'brien", "%", "baseline", expiresAt)
+	wantRevert := "CREATE EVENT `" + name + "` ON SCHEDULE AT FROM_UNIXTIME(1792346400) ON COMPLETION NOT PRESERVE " +
+		"COMMENT 'Managed by Terraform: audit log filter assignment expiry' " +
+		`DO SET @auditlogfilter_expiry = audit_log_filter_set_user('''o''''brien''@''%''', 'baseline')`
+	if revert != wantRevert {
+		t.Fatalf("expiryEventStatement() =\n%s\nwant\n%s", revert, wantRevert)
+	}
+
+	remove := expiryEventStatement(name, "%", "", "", expiresAt)
+	if !strings.HasSuffix(remove, "DO SET @auditlogfilter_expiry = audit_log_filter_remove_user('%')") {
+		t.Fatalf("unexpected removal statement %s", remove)
+	}
+}
+
+func TestSplitEventName(t *testing.T) {
+	t.Parallel()
+
+	schemaName, name := splitEventName("ops.db." + expiryEventPrefix + "abc")
+	if schemaName != "ops.db" || name != expiryEventPrefix+"abc" {
+		t.Fatalf("splitEventName() = %q, %q", schemaName, name)
+	}
+}
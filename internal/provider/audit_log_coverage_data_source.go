@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Coverage statuses reported for each account.
+const (
+	coverageExplicit  = "explicit"
+	coverageDefault   = "default"
+	coverageUnaudited = "unaudited"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditLogCoverageDataSource{}
+var _ datasource.DataSourceWithConfigure = &AuditLogCoverageDataSource{}
+
+func NewAuditLogCoverageDataSource() datasource.DataSource {
+	return &AuditLogCoverageDataSource{}
+}
+
+// AuditLogCoverageDataSource defines the data source implementation.
+type AuditLogCoverageDataSource struct {
+	db *mysqlClient
+}
+
+// AuditLogCoverageDataSourceModel describes the data source data model.
+type AuditLogCoverageDataSourceModel struct {
+	Accounts          []AuditLogCoverageAccountModel `tfsdk:"accounts"`
+	UnauditedAccounts []types.String                 `tfsdk:"unaudited_accounts"`
+}
+
+// AuditLogCoverageAccountModel describes the coverage of one account.
+type AuditLogCoverageAccountModel struct {
+	Account       types.String   `tfsdk:"account"`
+	Username      types.String   `tfsdk:"username"`
+	Userhost      types.String   `tfsdk:"userhost"`
+	Privileged    types.Bool     `tfsdk:"privileged"`
+	Locked        types.Bool     `tfsdk:"locked"`
+	Status        types.String   `tfsdk:"status"`
+	FilterName    types.String   `tfsdk:"filter_name"`
+	LoggedClasses []types.String `tfsdk:"logged_classes"`
+	Summary       types.String   `tfsdk:"summary"`
+}
+
+func (d *AuditLogCoverageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coverage"
+}
+
+func (d *AuditLogCoverageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports audit coverage for every account in `mysql.user`.\n\n" +
+			"Each account is covered by its own assignment in `mysql.audit_log_user` (`explicit`), by the `%` " +
+			"default assignment (`default`), or not at all (`unaudited`). The effective filter's stored definition " +
+			"is evaluated offline to list the event classes it logs.",
+
+		Attributes: map[string]schema.Attribute{
+			"accounts": schema.ListNestedAttribute{
+				Description: "Coverage of each account, ordered by username and host.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account": schema.StringAttribute{
+							Description: "Account name, quoted as 'user'@'host'.",
+							Computed:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "User part of the account.",
+							Computed:    true,
+						},
+						"userhost": schema.StringAttribute{
+							Description: "Host part of the account.",
+							Computed:    true,
+						},
+						"privileged": schema.BoolAttribute{
+							Description: "Whether the account holds SUPER, GRANT OPTION or CREATE USER.",
+							Computed:    true,
+						},
+						"locked": schema.BoolAttribute{
+							Description: "Whether the account is locked and cannot connect.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "One of 'explicit', 'default' or 'unaudited'.",
+							Computed:    true,
+						},
+						"filter_name": schema.StringAttribute{
+							Description: "Name of the effective filter. Null when the account is unaudited.",
+							Computed:    true,
+						},
+						"logged_classes": schema.ListAttribute{
+							Description: "Event classes the effective filter logs at least some events of, e.g. [\"connection\", \"table_access\"]. Empty when the account is unaudited.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"summary": schema.StringAttribute{
+							Description: "Human-readable description of the effective filter. Null when the account is unaudited.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"unaudited_accounts": schema.ListAttribute{
+				Description: "Accounts, quoted as 'user'@'host', that no filter applies to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AuditLogCoverageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+func (d *AuditLogCoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkConnected(&resp.Diagnostics, d.db) {
+		return
+	}
+
+	accounts, err := listMySQLAccounts(ctx, d.db)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read accounts: "+err.Error())
+		return
+	}
+
+	users, err := listAuditLogUsers(ctx, d.db, auditLogUserQuery{})
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read user assignments: "+err.Error())
+		return
+	}
+
+	filters, err := listAuditLogFilters(ctx, d.db, "")
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read filters: "+err.Error())
+		return
+	}
+
+	data := auditLogCoverage(accounts, users, filters)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// auditLogCoverage resolves the effective filter of every account.
+func auditLogCoverage(accounts []mysqlAccount, users []auditLogUserRow, filters []auditLogFilterRow) AuditLogCoverageDataSourceModel {
+	definitions := make(map[string]string, len(filters))
+	for _, filter := range filters {
+		definitions[filter.name] = filter.definition
+	}
+
+	data := AuditLogCoverageDataSourceModel{
+		Accounts:          make([]AuditLogCoverageAccountModel, 0, len(accounts)),
+		UnauditedAccounts: []types.String{},
+	}
+
+	for _, account := range accounts {
+		accountName := formatAccountName(account.username, account.userhost)
+		coverage := AuditLogCoverageAccountModel{
+			Account:       types.StringValue(accountName),
+			Username:      types.StringValue(account.username),
+			Userhost:      types.StringValue(account.userhost),
+			Privileged:    types.BoolValue(account.privileged),
+			Locked:        types.BoolValue(account.locked),
+			Status:        types.StringValue(coverageUnaudited),
+			FilterName:    types.StringNull(),
+			LoggedClasses: []types.String{},
+			Summary:       types.StringNull(),
+		}
+
+		assignment, ok := effectiveAssignment(users, account.username, account.userhost)
+		if !ok {
+			data.UnauditedAccounts = append(data.UnauditedAccounts, types.StringValue(accountName))
+			data.Accounts = append(data.Accounts, coverage)
+			continue
+		}
+
+		coverage.Status = types.StringValue(coverageExplicit)
+		if assignment.username == defaultAccount && account.username != defaultAccount {
+			coverage.Status = types.StringValue(coverageDefault)
+		}
+		coverage.FilterName = types.StringValue(assignment.filterName)
+
+		if definition, ok := definitions[assignment.filterName]; ok {
+			coverage.Summary = definitionSummary(definition)
+			if classes, err := definitionLoggedClasses(definition); err == nil {
+				for _, class := range classes {
+					coverage.LoggedClasses = append(coverage.LoggedClasses, types.StringValue(class))
+				}
+			}
+		}
+
+		data.Accounts = append(data.Accounts, coverage)
+	}
+
+	return data
+}
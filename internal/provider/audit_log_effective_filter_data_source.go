@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditLogEffectiveFilterDataSource{}
+var _ datasource.DataSourceWithConfigure = &AuditLogEffectiveFilterDataSource{}
+
+func NewAuditLogEffectiveFilterDataSource() datasource.DataSource {
+	return &AuditLogEffectiveFilterDataSource{}
+}
+
+// AuditLogEffectiveFilterDataSource defines the data source implementation.
+type AuditLogEffectiveFilterDataSource struct {
+	db *mysqlClient
+}
+
+// AuditLogEffectiveFilterDataSourceModel describes the data source data model.
+type AuditLogEffectiveFilterDataSourceModel struct {
+	User               types.String `tfsdk:"user"`
+	Host               types.String `tfsdk:"host"`
+	Account            types.String `tfsdk:"account"`
+	Audited            types.Bool   `tfsdk:"audited"`
+	AssignmentUsername types.String `tfsdk:"assignment_username"`
+	AssignmentUserhost types.String `tfsdk:"assignment_userhost"`
+	DefaultAssignment  types.Bool   `tfsdk:"default_assignment"`
+	FilterName         types.String `tfsdk:"filter_name"`
+	Definition         types.String `tfsdk:"definition"`
+}
+
+func (d *AuditLogEffectiveFilterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_effective_filter"
+}
+
+func (d *AuditLogEffectiveFilterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves which audit log filter applies to a client connecting as a user from a host.\n\n" +
+			"The server first authenticates the connection as one account from `mysql.user`, trying accounts in its " +
+			"precedence order: IPv4 hosts with a netmask, narrowest first, then other hosts, including plain IP " +
+			"addresses, from most to least specific, with named users before the anonymous user. The filter " +
+			"assigned to exactly that account applies; otherwise the `%` default assignment applies, and without " +
+			"one the connection is not audited.",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				Description: "User name the client connects as.",
+				Required:    true,
+			},
+			"host": schema.StringAttribute{
+				Description: "Host name or IP address the client connects from.",
+				Required:    true,
+			},
+			"account": schema.StringAttribute{
+				Description: "Account the connection is authenticated as, quoted as 'user'@'host'. Null when no account matches and the connection would be refused.",
+				Computed:    true,
+			},
+			"audited": schema.BoolAttribute{
+				Description: "Whether a filter applies to the connection.",
+				Computed:    true,
+			},
+			"assignment_username": schema.StringAttribute{
+				Description: "Username of the winning mysql.audit_log_user row.",
+				Computed:    true,
+			},
+			"assignment_userhost": schema.StringAttribute{
+				Description: "Host of the winning mysql.audit_log_user row.",
+				Computed:    true,
+			},
+			"default_assignment": schema.BoolAttribute{
+				Description: "Whether the filter comes from the '%' default assignment rather than an assignment of the account itself.",
+				Computed:    true,
+			},
+			"filter_name": schema.StringAttribute{
+				Description: "Name of the filter that applies to the connection.",
+				Computed:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "JSON definition of the filter that applies to the connection.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AuditLogEffectiveFilterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.db = db
+}
+
+func (d *AuditLogEffectiveFilterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if !checkConnected(&resp.Diagnostics, d.db) {
+		return
+	}
+
+	var data AuditLogEffectiveFilterDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Account = types.StringNull()
+	data.Audited = types.BoolValue(false)
+	data.AssignmentUsername = types.StringNull()
+	data.AssignmentUserhost = types.StringNull()
+	data.DefaultAssignment = types.BoolNull()
+	data.FilterName = types.StringNull()
+	data.Definition = types.StringNull()
+
+	accounts, err := listMySQLAccounts(ctx, d.db)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read accounts: "+err.Error())
+		return
+	}
+
+	account, ok := authenticatedAccount(accounts, data.User.ValueString(), data.Host.ValueString())
+	if !ok {
+		resp.Diagnostics.AddWarning(
+			"No Matching Account",
+			fmt.Sprintf("No account in mysql.user matches user '%s' connecting from '%s'; the server would refuse the connection.",
+				data.User.ValueString(), data.Host.ValueString()),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	data.Account = types.StringValue(formatAccountName(account.username, account.userhost))
+
+	assignment, ok, err := effectiveAssignmentForAccount(ctx, d.db, account.username, account.userhost)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read user assignments: "+err.Error())
+		return
+	}
+
+	if ok {
+		data.Audited = types.BoolValue(true)
+		data.AssignmentUsername = types.StringValue(assignment.username)
+		data.AssignmentUserhost = types.StringValue(assignment.userhost)
+		data.DefaultAssignment = types.BoolValue(assignment.username == defaultAccount)
+		data.FilterName = types.StringValue(assignment.filterName)
+
+		var definition string
+		err := d.db.QueryRowContext(ctx, "SELECT filter FROM mysql.audit_log_filter WHERE name = ?", assignment.filterName).Scan(&definition)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			resp.Diagnostics.AddWarning(
+				"Assigned Filter Not Found",
+				fmt.Sprintf("The assignment refers to filter '%s', which does not exist.", assignment.filterName),
+			)
+		case err != nil:
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read filter: "+err.Error())
+			return
+		default:
+			normalizedDefinition, err := normalizeJSON(definition)
+			if err != nil {
+				resp.Diagnostics.AddError("Database Error", "Failed to normalize filter definition: "+err.Error())
+				return
+			}
+			data.Definition = types.StringValue(normalizedDefinition)
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &AuditLogFilterListResource{}
+var _ list.ListResourceWithConfigure = &AuditLogFilterListResource{}
+
+func NewAuditLogFilterListResource() list.ListResource {
+	return &AuditLogFilterListResource{}
+}
+
+// AuditLogFilterListResource lists the filters in mysql.audit_log_filter.
+type AuditLogFilterListResource struct {
+	db *mysqlClient
+}
+
+// AuditLogFilterListResourceModel describes the list block configuration.
+type AuditLogFilterListResourceModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *AuditLogFilterListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_filter"
+}
+
+func (r *AuditLogFilterListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the audit log filters on the server for bulk import.",
+
+		Attributes: map[string]listschema.Attribute{
+			"name": listschema.StringAttribute{
+				Description: "SQL LIKE pattern the filter name must match, e.g. 'app_%'. Lists every filter when omitted.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogFilterListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *AuditLogFilterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config AuditLogFilterListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if !checkConnected(&diags, r.db) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filters, err := listAuditLogFilters(ctx, r.db, config.Name.ValueString())
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to list filters: "+err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, row := range filters {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = row.name
+			result.Diagnostics.Append(r.listResult(ctx, req, row, &result)...)
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+func (r *AuditLogFilterListResource) listResult(ctx context.Context, req list.ListRequest, row auditLogFilterRow, result *list.ListResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(result.Identity.Set(ctx, AuditLogFilterIdentityModel{Name: types.StringValue(row.name)})...)
+
+	if !req.IncludeResource {
+		return diags
+	}
+
+	data, err := newAuditLogFilterModel(row)
+	if err != nil {
+		diags.AddError("Database Error", fmt.Sprintf("Failed to normalize definition of filter '%s': %s", row.name, err.Error()))
+		return diags
+	}
+
+	diags.Append(result.Resource.Set(ctx, &data)...)
+	return diags
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -19,6 +21,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AuditLogFilterResource{}
 var _ resource.ResourceWithImportState = &AuditLogFilterResource{}
+var _ resource.ResourceWithModifyPlan = &AuditLogFilterResource{}
+var _ resource.ResourceWithIdentity = &AuditLogFilterResource{}
 
 func NewAuditLogFilterResource() resource.Resource {
 	return &AuditLogFilterResource{}
@@ -38,15 +42,39 @@ func normalizeJSON(input string) (string, error) {
 
 // AuditLogFilterResource defines the resource implementation.
 type AuditLogFilterResource struct {
-	db *sql.DB
+	db *mysqlClient
 }
 
 // AuditLogFilterResourceModel describes the resource data model.
 type AuditLogFilterResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Definition types.String `tfsdk:"definition"`
-	FilterID   types.Int64  `tfsdk:"filter_id"`
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Definition     types.String `tfsdk:"definition"`
+	FilterID       types.Int64  `tfsdk:"filter_id"`
+	AllowSelfAbort types.Bool   `tfsdk:"allow_self_abort"`
+	Summary        types.String `tfsdk:"summary"`
+}
+
+// AuditLogFilterIdentityModel describes the resource identity data model.
+type AuditLogFilterIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+// newAuditLogFilterModel builds the state of a filter from its mysql.audit_log_filter row.
+func newAuditLogFilterModel(row auditLogFilterRow) (AuditLogFilterResourceModel, error) {
+	normalizedDefinition, err := normalizeJSON(row.definition)
+	if err != nil {
+		return AuditLogFilterResourceModel{}, err
+	}
+
+	return AuditLogFilterResourceModel{
+		ID:             types.StringValue(row.name),
+		Name:           types.StringValue(row.name),
+		Definition:     types.StringValue(normalizedDefinition),
+		FilterID:       types.Int64Value(row.filterID),
+		AllowSelfAbort: types.BoolNull(),
+		Summary:        definitionSummary(normalizedDefinition),
+	}, nil
 }
 
 func (r *AuditLogFilterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,6 +115,27 @@ func (r *AuditLogFilterResource) Schema(ctx context.Context, req resource.Schema
 				Description: "Internal filter ID assigned by MySQL.",
 				Computed:    true,
 			},
+			"allow_self_abort": schema.BoolAttribute{
+				Description: "Allow a definition with \"abort\" rules even when the filter applies to the account the provider " +
+					"connects as, either directly or through the '%' default. Defaults to false, which fails the plan " +
+					"to avoid locking the provider out.",
+				Optional: true,
+			},
+			"summary": schema.StringAttribute{
+				Description: "Human-readable description of the filter definition, e.g. which event classes are logged and which are aborted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogFilterResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "Name of the audit log filter.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -97,12 +146,12 @@ func (r *AuditLogFilterResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	db, ok := req.ProviderData.(*sql.DB)
+	db, ok := req.ProviderData.(*mysqlClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sql.DB, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -111,7 +160,99 @@ func (r *AuditLogFilterResource) Configure(ctx context.Context, req resource.Con
 	r.db = db
 }
 
+func (r *AuditLogFilterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AuditLogFilterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state *AuditLogFilterResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &AuditLogFilterResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Definition.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("summary"), definitionSummary(plan.Definition.ValueString()))...)
+	}
+
+	// Live server checks need a configured provider.
+	if r.db == nil {
+		return
+	}
+
+	// A new name must not collide with a filter this resource does not
+	// manage. Names planned here let assignments planned later in the run
+	// refer to the filter before it exists.
+	if !plan.Name.IsUnknown() && (state == nil || !state.Name.Equal(plan.Name)) {
+		name := plan.Name.ValueString()
+		r.db.planFilter(name)
+
+		exists, err := filterExists(ctx, r.db, name)
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing filter: "+err.Error())
+			return
+		}
+		if exists {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Filter Already Exists",
+				fmt.Sprintf("A filter with name '%s' already exists and is not managed by this resource. "+
+					"Import it with terraform import, or choose another name.", name),
+			)
+			return
+		}
+	}
+
+	if plan.Name.IsUnknown() || plan.Definition.IsUnknown() {
+		return
+	}
+
+	// Assignments planned later in the run check the definition the filter
+	// will have, which may not be on the server yet.
+	r.db.planFilterDefinition(plan.Name.ValueString(), plan.Definition.ValueString())
+
+	if plan.AllowSelfAbort.ValueBool() {
+		return
+	}
+
+	if !definitionAborts(plan.Definition.ValueString()) {
+		return
+	}
+
+	username, userhost, err := queryCurrentAccount(ctx, r.db)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to determine the provider account: "+err.Error())
+		return
+	}
+
+	effectiveFilter, err := effectiveFilterForAccount(ctx, r.db, username, userhost)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check the provider account's filter assignment: "+err.Error())
+		return
+	}
+
+	if effectiveFilter == plan.Name.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Filter Would Abort Provider Account",
+			selfAbortDetail(username, userhost, effectiveFilter),
+		)
+	}
+}
+
 func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "create audit log filters") {
+		return
+	}
+
 	var data AuditLogFilterResourceModel
 
 	// Read Terraform plan data into the model
@@ -121,6 +262,12 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.Name.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	// Validate filter definition structure and syntax
 	if err := validateAuditLogFilterDefinition(data.Definition.ValueString()); err != nil {
 		resp.Diagnostics.AddAttributeError(
@@ -145,7 +292,7 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 	var existingCount int
 	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_filter WHERE name = ?", data.Name.ValueString()).Scan(&existingCount)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to check existing filter: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing filter: "+err.Error())
 		return
 	}
 
@@ -162,7 +309,7 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 	var result string
 	err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_filter(?, ?)", data.Name.ValueString(), normalizedDefinition).Scan(&result)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Root("definition"), err,
 			"Failed to Create Filter",
 			"Could not create audit log filter: "+err.Error(),
 		)
@@ -170,7 +317,7 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 	}
 
 	if result != "OK" {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Root("definition"), auditResultError(result),
 			"Filter Creation Failed",
 			"MySQL returned an error: "+result,
 		)
@@ -181,7 +328,7 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 	var filterID int64
 	err = r.db.QueryRowContext(ctx, "SELECT filter_id FROM mysql.audit_log_filter WHERE name = ?", data.Name.ValueString()).Scan(&filterID)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to retrieve filter ID: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to retrieve filter ID: "+err.Error())
 		return
 	}
 
@@ -189,12 +336,20 @@ func (r *AuditLogFilterResource) Create(ctx context.Context, req resource.Create
 	data.ID = data.Name
 	data.FilterID = types.Int64Value(filterID)
 	data.Definition = types.StringValue(normalizedDefinition)
+	data.Summary = definitionSummary(normalizedDefinition)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuditLogFilterIdentityModel{Name: data.Name})...)
 }
 
 func (r *AuditLogFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Without a connection, while the provider configuration is unknown,
+	// the prior state is kept.
+	if r.db == nil {
+		return
+	}
+
 	var data AuditLogFilterResourceModel
 
 	// Read Terraform prior state data into the model
@@ -214,7 +369,7 @@ func (r *AuditLogFilterResource) Read(ctx context.Context, req resource.ReadRequ
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Database Error", "Failed to read filter: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read filter: "+err.Error())
 		return
 	}
 
@@ -227,13 +382,19 @@ func (r *AuditLogFilterResource) Read(ctx context.Context, req resource.ReadRequ
 	// Update the model with current database values
 	data.FilterID = types.Int64Value(filterID)
 	data.Definition = types.StringValue(normalizedDefinition)
+	data.Summary = definitionSummary(normalizedDefinition)
 	data.ID = data.Name
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuditLogFilterIdentityModel{Name: data.Name})...)
 }
 
 func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "update audit log filters") {
+		return
+	}
+
 	var data AuditLogFilterResourceModel
 
 	// Read Terraform plan data into the model
@@ -243,6 +404,12 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, true, data.Name.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	// Validate filter definition structure and syntax
 	if err := validateAuditLogFilterDefinition(data.Definition.ValueString()); err != nil {
 		resp.Diagnostics.AddAttributeError(
@@ -281,7 +448,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 		filterName,
 	).Scan(&oldDefinition)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to read existing filter definition: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read existing filter definition: "+err.Error())
 		return
 	}
 
@@ -294,7 +461,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 
 	rows, err := r.db.QueryContext(ctx, "SELECT username, userhost FROM mysql.audit_log_user WHERE filtername = ?", filterName)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to check user assignments: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check user assignments: "+err.Error())
 		return
 	}
 	defer func() {
@@ -306,14 +473,14 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 	for rows.Next() {
 		var user userAssignment
 		if err := rows.Scan(&user.username, &user.userhost); err != nil {
-			resp.Diagnostics.AddError("Database Error", "Failed to scan user assignments: "+err.Error())
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to scan user assignments: "+err.Error())
 			return
 		}
 		assignedUsers = append(assignedUsers, user)
 	}
 
 	if err := rows.Err(); err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to iterate user assignments: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to iterate user assignments: "+err.Error())
 		return
 	}
 
@@ -321,7 +488,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 	var removeResult string
 	err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_filter(?)", filterName).Scan(&removeResult)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), err,
 			"Failed to Remove Existing Filter",
 			"Could not remove existing audit log filter during update: "+err.Error(),
 		)
@@ -329,7 +496,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 	}
 
 	if removeResult != "OK" {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), auditResultError(removeResult),
 			"Filter Removal Failed",
 			"MySQL returned an error when removing existing filter: "+removeResult,
 		)
@@ -347,14 +514,14 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 			oldDefinition,
 		).Scan(&rollbackResult)
 		if rbErr != nil || rollbackResult != "OK" {
-			resp.Diagnostics.AddError(
+			addMySQLError(&resp.Diagnostics, path.Root("definition"), err,
 				"Failed to Recreate Filter",
 				"Could not recreate audit log filter with new definition: "+err.Error()+
 					". Rollback attempt also failed; manual restoration may be required.",
 			)
 			return
 		}
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Root("definition"), err,
 			"Failed to Recreate Filter",
 			"Could not recreate audit log filter with new definition: "+err.Error()+
 				". The original filter definition was restored.",
@@ -370,14 +537,14 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 			oldDefinition,
 		).Scan(&rollbackResult)
 		if rbErr != nil || rollbackResult != "OK" {
-			resp.Diagnostics.AddError(
+			addMySQLError(&resp.Diagnostics, path.Root("definition"), auditResultError(createResult),
 				"Filter Recreation Failed",
 				"MySQL returned an error when recreating filter: "+createResult+
 					". Rollback attempt also failed; manual restoration may be required.",
 			)
 			return
 		}
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Root("definition"), auditResultError(createResult),
 			"Filter Recreation Failed",
 			"MySQL returned an error when recreating filter: "+createResult+
 				". The original filter definition was restored.",
@@ -394,12 +561,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 		)
 
 		for _, user := range assignedUsers {
-			var userSpec string
-			if user.username == "%" {
-				userSpec = "%"
-			} else {
-				userSpec = fmt.Sprintf("%s@%s", user.username, user.userhost)
-			}
+			userSpec := buildUserSpec(user.username, user.userhost)
 
 			var assignResult string
 			err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", userSpec, filterName).Scan(&assignResult)
@@ -426,7 +588,7 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 	var filterID int64
 	err = r.db.QueryRowContext(ctx, "SELECT filter_id FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&filterID)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to retrieve updated filter: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to retrieve updated filter: "+err.Error())
 		return
 	}
 
@@ -434,12 +596,18 @@ func (r *AuditLogFilterResource) Update(ctx context.Context, req resource.Update
 	data.FilterID = types.Int64Value(filterID)
 	data.ID = data.Name
 	data.Definition = types.StringValue(normalizedDefinition)
+	data.Summary = definitionSummary(normalizedDefinition)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuditLogFilterIdentityModel{Name: data.Name})...)
 }
 
 func (r *AuditLogFilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "delete audit log filters") {
+		return
+	}
+
 	var data AuditLogFilterResourceModel
 
 	// Read Terraform prior state data into the model
@@ -449,11 +617,17 @@ func (r *AuditLogFilterResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.Name.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	// Remove the audit log filter using the MySQL function - use direct query
 	var result string
 	err := r.db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_filter(?)", data.Name.ValueString()).Scan(&result)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), err,
 			"Failed to Delete Filter",
 			"Could not delete audit log filter: "+err.Error(),
 		)
@@ -461,7 +635,7 @@ func (r *AuditLogFilterResource) Delete(ctx context.Context, req resource.Delete
 	}
 
 	if result != "OK" {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), auditResultError(result),
 			"Filter Deletion Failed",
 			"MySQL returned an error: "+result,
 		)
@@ -470,13 +644,20 @@ func (r *AuditLogFilterResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *AuditLogFilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by filter name
-	filterName := req.ID
+	if !checkConnected(&resp.Diagnostics, r.db) {
+		return
+	}
+
+	filterName, diags := filterNameFromImport(ctx, req)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Validate that the filter exists
-	var filterID int64
-	var definition string
-	err := r.db.QueryRowContext(ctx, "SELECT filter_id, filter FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&filterID, &definition)
+	row := auditLogFilterRow{name: filterName}
+	err := r.db.QueryRowContext(ctx, "SELECT filter_id, filter FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&row.filterID, &row.definition)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			resp.Diagnostics.AddError(
@@ -485,23 +666,29 @@ func (r *AuditLogFilterResource) ImportState(ctx context.Context, req resource.I
 			)
 			return
 		}
-		resp.Diagnostics.AddError("Database Error", "Failed to query filter: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to query filter: "+err.Error())
 		return
 	}
 
-	normalizedDefinition, err := normalizeJSON(definition)
+	data, err := newAuditLogFilterModel(row)
 	if err != nil {
 		resp.Diagnostics.AddError("Database Error", "Failed to normalize filter definition: "+err.Error())
 		return
 	}
 
 	// Set the state
-	data := AuditLogFilterResourceModel{
-		ID:         types.StringValue(filterName),
-		Name:       types.StringValue(filterName),
-		Definition: types.StringValue(normalizedDefinition),
-		FilterID:   types.Int64Value(filterID),
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, AuditLogFilterIdentityModel{Name: data.Name})...)
+}
+
+// filterNameFromImport returns the filter name from the import ID, or from the
+// identity of an import block that uses identity = { name = ... }.
+func filterNameFromImport(ctx context.Context, req resource.ImportStateRequest) (string, diag.Diagnostics) {
+	if req.ID != "" {
+		return req.ID, nil
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var identity AuditLogFilterIdentityModel
+	diags := req.Identity.Get(ctx, &identity)
+	return identity.Name.ValueString(), diags
 }
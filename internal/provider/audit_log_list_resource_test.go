@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestListResourcesHaveIdentitySchemas(t *testing.T) {
+	t.Parallel()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected provider server error: %v", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() error = %v", err)
+	}
+	for _, diagnostic := range schemaResp.Diagnostics {
+		t.Fatalf("unexpected schema diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	identityResp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("GetResourceIdentitySchemas() error = %v", err)
+	}
+	for _, diagnostic := range identityResp.Diagnostics {
+		t.Fatalf("unexpected identity schema diagnostic: %s: %s", diagnostic.Summary, diagnostic.Detail)
+	}
+
+	for _, typeName := range []string{"auditlogfilters_filter", "auditlogfilters_user_assignment"} {
+		if _, ok := schemaResp.ListResourceSchemas[typeName]; !ok {
+			t.Fatalf("missing list resource schema for %s", typeName)
+		}
+		if _, ok := identityResp.IdentitySchemas[typeName]; !ok {
+			t.Fatalf("missing identity schema for %s", typeName)
+		}
+	}
+}
+
+func TestNewAuditLogFilterModel(t *testing.T) {
+	t.Parallel()
+
+	data, err := newAuditLogFilterModel(auditLogFilterRow{
+		filterID:   7,
+		name:       "log_connections",
+		definition: `{ "filter": { "class": { "name": "connection" } } }`,
+	})
+	if err != nil {
+		t.Fatalf("newAuditLogFilterModel() error = %v", err)
+	}
+
+	if data.ID.ValueString() != "log_connections" || data.FilterID.ValueInt64() != 7 {
+		t.Fatalf("unexpected model: %+v", data)
+	}
+	if data.Definition.ValueString() != `{"filter":{"class":{"name":"connection"}}}` {
+		t.Fatalf("definition not normalized: %s", data.Definition.ValueString())
+	}
+	if data.Summary.IsNull() {
+		t.Fatalf("expected summary to be set")
+	}
+
+	if _, err := newAuditLogFilterModel(auditLogFilterRow{name: "broken", definition: "{"}); err == nil {
+		t.Fatalf("expected error for invalid stored definition")
+	}
+}
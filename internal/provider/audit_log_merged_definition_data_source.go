@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditLogMergedDefinitionDataSource{}
+
+func NewAuditLogMergedDefinitionDataSource() datasource.DataSource {
+	return &AuditLogMergedDefinitionDataSource{}
+}
+
+// AuditLogMergedDefinitionDataSource defines the data source implementation.
+type AuditLogMergedDefinitionDataSource struct{}
+
+// AuditLogMergedDefinitionDataSourceModel describes the data source data model.
+type AuditLogMergedDefinitionDataSourceModel struct {
+	Fragments  []types.String `tfsdk:"fragments"`
+	Definition types.String   `tfsdk:"definition"`
+}
+
+func (d *AuditLogMergedDefinitionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_merged_definition"
+}
+
+func (d *AuditLogMergedDefinitionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Combines several audit log filter definition fragments into a single definition.\n\n" +
+			"Only one filter can be assigned to an account, so teams that own different audit concerns can each " +
+			"provide a fragment and assign the merged result. Class and event rules are OR-ed together and " +
+			"identical branches are deduplicated. Fragments whose `abort` or `\"log\": false` rules would change " +
+			"meaning when combined are reported as errors.",
+
+		Attributes: map[string]schema.Attribute{
+			"fragments": schema.ListAttribute{
+				Description: "Filter definition fragments to merge. Each fragment must be a valid audit log filter definition.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"definition": schema.StringAttribute{
+				Description: "Merged JSON filter definition, suitable for the definition attribute of auditlogfilters_filter.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AuditLogMergedDefinitionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditLogMergedDefinitionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fragments := make([]string, 0, len(data.Fragments))
+	for i, fragment := range data.Fragments {
+		if fragment.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fragments").AtListIndex(i),
+				"Invalid Definition Fragment",
+				"Definition fragments must not be null.",
+			)
+			return
+		}
+		if err := validateAuditLogFilterDefinition(fragment.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("fragments").AtListIndex(i),
+				"Invalid JSON Definition",
+				err.Error(),
+			)
+			return
+		}
+		fragments = append(fragments, fragment.ValueString())
+	}
+
+	merged, err := mergeAuditLogFilterDefinitions(fragments)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fragments"),
+			"Definition Fragments Cannot Be Merged",
+			err.Error(),
+		)
+		return
+	}
+
+	data.Definition = types.StringValue(merged)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
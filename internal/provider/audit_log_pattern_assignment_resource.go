@@ -0,0 +1,441 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AuditLogPatternAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &AuditLogPatternAssignmentResource{}
+var _ resource.ResourceWithValidateConfig = &AuditLogPatternAssignmentResource{}
+
+func NewAuditLogPatternAssignmentResource() resource.Resource {
+	return &AuditLogPatternAssignmentResource{}
+}
+
+// AuditLogPatternAssignmentResource defines the resource implementation.
+type AuditLogPatternAssignmentResource struct {
+	db *mysqlClient
+}
+
+// AuditLogPatternAssignmentResourceModel describes the resource data model.
+type AuditLogPatternAssignmentResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	UsernamePattern types.String `tfsdk:"username_pattern"`
+	UserhostPattern types.String `tfsdk:"userhost_pattern"`
+	PatternType     types.String `tfsdk:"pattern_type"`
+	FilterName      types.String `tfsdk:"filter_name"`
+	AllowSelfAbort  types.Bool   `tfsdk:"allow_self_abort"`
+	Members         types.List   `tfsdk:"members"`
+	Conflicts       types.List   `tfsdk:"conflicts"`
+}
+
+func (m AuditLogPatternAssignmentResourceModel) pattern() accountPattern {
+	return accountPattern{
+		kind:     m.PatternType.ValueString(),
+		username: m.UsernamePattern.ValueString(),
+		userhost: m.UserhostPattern.ValueString(),
+	}
+}
+
+// patternAssignmentID identifies a pattern assignment by its patterns, e.g.
+// like:'svc\_%'@'%'. A null userhost pattern is shown as an empty host.
+func patternAssignmentID(pattern accountPattern) string {
+	return pattern.kind + ":" + formatAccountName(pattern.username, pattern.userhost)
+}
+
+func (r *AuditLogPatternAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pattern_assignment"
+}
+
+func (r *AuditLogPatternAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns an audit log filter to every account in `mysql.user` whose name matches a pattern.\n\n" +
+			"Matching accounts are re-evaluated on every plan, so accounts created or dropped since the last apply " +
+			"show up as changes to `members`. Each account gets its own assignment through " +
+			"`audit_log_filter_set_user()`. Accounts that already have an assignment this resource does not manage " +
+			"are left alone and reported in `conflicts`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for the pattern assignment, the pattern type followed by the quoted patterns, e.g. like:'svc\\_%'@'%'.",
+				Computed:    true,
+			},
+			"username_pattern": schema.StringAttribute{
+				Description: "Pattern the user name of an account must match.",
+				Required:    true,
+			},
+			"userhost_pattern": schema.StringAttribute{
+				Description: "Pattern the host of an account must match, compared case-insensitively. Omit to match accounts on any host.",
+				Optional:    true,
+			},
+			"pattern_type": schema.StringAttribute{
+				Description: "How the patterns are interpreted: 'like' for SQL LIKE patterns with '%' and '_' wildcards, " +
+					"or 'regex' for RE2 regular expressions that must match the whole name. Defaults to 'like'.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(accountPatternLike),
+			},
+			"filter_name": schema.StringAttribute{
+				Description: "Name of the audit log filter to assign to the matching accounts. The filter must exist.",
+				Required:    true,
+			},
+			"allow_self_abort": schema.BoolAttribute{
+				Description: "Allow assigning a filter with \"abort\" rules when the account the provider connects as " +
+					"matches. Defaults to false, which fails the plan to avoid locking the provider out.",
+				Optional: true,
+			},
+			"members": schema.ListAttribute{
+				Description: "Accounts, quoted as 'user'@'host', that this resource assigns the filter to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"conflicts": schema.ListAttribute{
+				Description: "Accounts, quoted as 'user'@'host', that match but already have an assignment this " +
+					"resource does not manage.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogPatternAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *AuditLogPatternAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AuditLogPatternAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UsernamePattern.IsUnknown() || data.UserhostPattern.IsUnknown() || data.PatternType.IsUnknown() {
+		return
+	}
+
+	pattern := data.pattern()
+	if data.PatternType.IsNull() {
+		pattern.kind = accountPatternLike
+	}
+
+	if _, err := pattern.matcher(); err != nil {
+		resp.Diagnostics.AddError("Invalid Account Pattern", err.Error())
+	}
+}
+
+func (r *AuditLogPatternAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.db == nil {
+		return
+	}
+
+	var plan AuditLogPatternAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.UsernamePattern.IsUnknown() || plan.UserhostPattern.IsUnknown() || plan.PatternType.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), patternAssignmentID(plan.pattern()))...)
+
+	// Accounts assigned earlier stay managed; those that no longer match are
+	// removed on apply.
+	managed := map[string]bool{}
+	if !req.State.Raw.IsNull() {
+		var state AuditLogPatternAssignmentResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		resp.Diagnostics.Append(addMembers(ctx, managed, state.Members)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	members, conflicts, err := r.resolveMembers(ctx, plan, managed)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to resolve matching accounts: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.Members, plan.Conflicts, diags = memberLists(ctx, members, conflicts)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("members"), plan.Members)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("conflicts"), plan.Conflicts)...)
+
+	if len(conflicts) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("username_pattern"),
+			"Conflicting Assignments",
+			fmt.Sprintf("These accounts match but already have an assignment this resource does not manage, "+
+				"so their filter is left unchanged: %s", strings.Join(conflicts, ", ")),
+		)
+	}
+
+	if plan.FilterName.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPlannedFilterExists(ctx, r.db, path.Root("filter_name"), plan.FilterName.ValueString())...)
+
+	if resp.Diagnostics.HasError() || plan.AllowSelfAbort.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkMembersSelfAbort(ctx, r.db, members, plan.FilterName.ValueString())...)
+}
+
+func (r *AuditLogPatternAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "create pattern assignments") {
+		return
+	}
+
+	var data AuditLogPatternAssignmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	filterName := data.FilterName.ValueString()
+
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), filterName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := r.plannedMembers(ctx, &data, map[string]bool{})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(patternAssignmentID(data.pattern()))
+
+	assigned, err := assignMembers(ctx, r.db, members, filterName)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Create Pattern Assignment", "Could not "+err.Error())
+
+		// Keep the accounts already assigned in state so they are cleaned up.
+		data.Members, _, diags = memberLists(ctx, assigned, nil)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogPatternAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Without a connection, while the provider configuration is unknown,
+	// the prior state is kept.
+	if r.db == nil {
+		return
+	}
+
+	var data AuditLogPatternAssignmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := assignedMembers(ctx, r.db, previous, data.FilterName.ValueString())
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read user assignments: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Members, _, diags = memberLists(ctx, members, nil)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogPatternAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "update pattern assignments") {
+		return
+	}
+
+	var data, state AuditLogPatternAssignmentResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString(), state.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	filterName := data.FilterName.ValueString()
+
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), filterName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := map[string]bool{}
+	resp.Diagnostics.Append(addMembers(ctx, managed, state.Members)...)
+
+	members, diags := r.plannedMembers(ctx, &data, managed)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(patternAssignmentID(data.pattern()))
+
+	// Assigning every member also moves existing members to a changed filter.
+	if _, err := assignMembers(ctx, r.db, members, filterName); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Update Pattern Assignment", "Could not "+err.Error())
+		return
+	}
+
+	// The remaining accounts no longer match or were dropped.
+	for _, member := range members {
+		delete(managed, member)
+	}
+	if err := removeMembers(ctx, r.db, sortedMembers(managed), state.FilterName.ValueString()); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Update Pattern Assignment", "Could not "+err.Error())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogPatternAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "delete pattern assignments") {
+		return
+	}
+
+	var data AuditLogPatternAssignmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := removeMembers(ctx, r.db, members, data.FilterName.ValueString()); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Failed to Delete Pattern Assignment", "Could not "+err.Error())
+		return
+	}
+}
+
+// resolveMembers reads the matching accounts and the existing assignments and
+// splits the accounts into members and conflicts.
+func (r *AuditLogPatternAssignmentResource) resolveMembers(ctx context.Context, data AuditLogPatternAssignmentResourceModel, managed map[string]bool) (members, conflicts []string, err error) {
+	accounts, err := listMySQLAccounts(ctx, r.db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matching, err := matchingAccounts(accounts, data.pattern())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users, err := listAuditLogUsers(ctx, r.db, auditLogUserQuery{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members, conflicts = bulkAssignmentMembers(matching, users, managed)
+	return members, conflicts, nil
+}
+
+// plannedMembers returns the members in the plan, resolving them now when the
+// plan could not, and stores them in data.
+func (r *AuditLogPatternAssignmentResource) plannedMembers(ctx context.Context, data *AuditLogPatternAssignmentResourceModel, managed map[string]bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.Members.IsUnknown() && !data.Conflicts.IsUnknown() {
+		var members []string
+		diags.Append(data.Members.ElementsAs(ctx, &members, false)...)
+		return members, diags
+	}
+
+	members, conflicts, err := r.resolveMembers(ctx, *data, managed)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to resolve matching accounts: "+err.Error())
+		return nil, diags
+	}
+
+	data.Members, data.Conflicts, diags = memberLists(ctx, members, conflicts)
+	return members, diags
+}
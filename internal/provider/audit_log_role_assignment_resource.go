@@ -0,0 +1,412 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AuditLogRoleAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &AuditLogRoleAssignmentResource{}
+
+func NewAuditLogRoleAssignmentResource() resource.Resource {
+	return &AuditLogRoleAssignmentResource{}
+}
+
+// AuditLogRoleAssignmentResource defines the resource implementation.
+type AuditLogRoleAssignmentResource struct {
+	db *mysqlClient
+}
+
+// AuditLogRoleAssignmentResourceModel describes the resource data model.
+type AuditLogRoleAssignmentResourceModel struct {
+	ID             types.String     `tfsdk:"id"`
+	Role           types.String     `tfsdk:"role"`
+	RoleHost       HostPatternValue `tfsdk:"role_host"`
+	FilterName     types.String     `tfsdk:"filter_name"`
+	AllowSelfAbort types.Bool       `tfsdk:"allow_self_abort"`
+	Members        types.List       `tfsdk:"members"`
+	Conflicts      types.List       `tfsdk:"conflicts"`
+}
+
+func (r *AuditLogRoleAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_assignment"
+}
+
+func (r *AuditLogRoleAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns an audit log filter to every account granted a role.\n\n" +
+			"The audit_log_filter component assigns filters to accounts, not roles, so this resource expands the role " +
+			"through `mysql.role_edges` and `mysql.default_roles`, including roles granted to other roles, and manages " +
+			"one assignment per account. Accounts that gain or lose the role show up as changes to `members` on the " +
+			"next plan. Accounts that already have an assignment this resource does not manage are left alone and " +
+			"reported in `conflicts`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier for the role assignment, the quoted role name 'role'@'role_host'.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "Name of the role whose grantees are assigned the filter.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_host": schema.StringAttribute{
+				Description: "Host part of the role name. Defaults to '%', the host of roles created without one.",
+				Optional:    true,
+				Computed:    true,
+				CustomType:  HostPatternType{},
+				Default:     stringdefault.StaticString("%"),
+				Validators: []validator.String{
+					hostPatternValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter_name": schema.StringAttribute{
+				Description: "Name of the audit log filter to assign to the role's grantees. The filter must exist.",
+				Required:    true,
+			},
+			"allow_self_abort": schema.BoolAttribute{
+				Description: "Allow assigning a filter with \"abort\" rules when the account the provider connects as is " +
+					"granted the role. Defaults to false, which fails the plan to avoid locking the provider out.",
+				Optional: true,
+			},
+			"members": schema.ListAttribute{
+				Description: "Accounts, quoted as 'user'@'host', that this resource assigns the filter to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"conflicts": schema.ListAttribute{
+				Description: "Accounts, quoted as 'user'@'host', that are granted the role but already have an " +
+					"assignment this resource does not manage.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogRoleAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *AuditLogRoleAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy or before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.db == nil {
+		return
+	}
+
+	var plan AuditLogRoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Role.IsUnknown() || plan.RoleHost.IsUnknown() {
+		return
+	}
+
+	// Accounts assigned earlier stay managed unless the role changes, which
+	// replaces the resource and removes them first.
+	managed := map[string]bool{}
+	if !req.State.Raw.IsNull() {
+		var state AuditLogRoleAssignmentResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if state.Role.Equal(plan.Role) && normalizeHostPattern(state.RoleHost.ValueString()) == normalizeHostPattern(plan.RoleHost.ValueString()) {
+			resp.Diagnostics.Append(addMembers(ctx, managed, state.Members)...)
+		}
+	}
+
+	members, conflicts, err := r.resolveMembers(ctx, plan, managed)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to resolve role members: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.Members, plan.Conflicts, diags = memberLists(ctx, members, conflicts)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("members"), plan.Members)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("conflicts"), plan.Conflicts)...)
+
+	if len(conflicts) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("role"),
+			"Conflicting Assignments",
+			fmt.Sprintf("These accounts are granted the role but already have an assignment this resource does not manage, "+
+				"so their filter is left unchanged: %s", strings.Join(conflicts, ", ")),
+		)
+	}
+
+	if plan.FilterName.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPlannedFilterExists(ctx, r.db, path.Root("filter_name"), plan.FilterName.ValueString())...)
+
+	if resp.Diagnostics.HasError() || plan.AllowSelfAbort.ValueBool() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkMembersSelfAbort(ctx, r.db, members, plan.FilterName.ValueString())...)
+}
+
+func (r *AuditLogRoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "create role assignments") {
+		return
+	}
+
+	var data AuditLogRoleAssignmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	roleHost := normalizeHostPattern(data.RoleHost.ValueString())
+	data.RoleHost = NewHostPatternValue(roleHost)
+	filterName := data.FilterName.ValueString()
+
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), filterName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := r.plannedMembers(ctx, &data, map[string]bool{})
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(formatAccountName(data.Role.ValueString(), roleHost))
+
+	assigned, err := assignMembers(ctx, r.db, members, filterName)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Create Role Assignment", "Could not "+err.Error())
+
+		// Keep the accounts already assigned in state so they are cleaned up.
+		data.Members, _, diags = memberLists(ctx, assigned, nil)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogRoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Without a connection, while the provider configuration is unknown,
+	// the prior state is kept.
+	if r.db == nil {
+		return
+	}
+
+	var data AuditLogRoleAssignmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &previous, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := assignedMembers(ctx, r.db, previous, data.FilterName.ValueString())
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read user assignments: "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Members, _, diags = memberLists(ctx, members, nil)
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogRoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "update role assignments") {
+		return
+	}
+
+	var data, state AuditLogRoleAssignmentResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString(), state.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	filterName := data.FilterName.ValueString()
+
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), filterName)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managed := map[string]bool{}
+	resp.Diagnostics.Append(addMembers(ctx, managed, state.Members)...)
+
+	members, diags := r.plannedMembers(ctx, &data, managed)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Assigning every member also moves existing members to a changed filter.
+	if _, err := assignMembers(ctx, r.db, members, filterName); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Update Role Assignment", "Could not "+err.Error())
+		return
+	}
+
+	// The remaining accounts lost the role.
+	for _, member := range members {
+		delete(managed, member)
+	}
+	if err := removeMembers(ctx, r.db, sortedMembers(managed), state.FilterName.ValueString()); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err, "Failed to Update Role Assignment", "Could not "+err.Error())
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AuditLogRoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "delete role assignments") {
+		return
+	}
+
+	var data AuditLogRoleAssignmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := removeMembers(ctx, r.db, members, data.FilterName.ValueString()); err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Failed to Delete Role Assignment", "Could not "+err.Error())
+		return
+	}
+}
+
+// resolveMembers reads the role's grantees and the existing assignments and
+// splits the grantees into members and conflicts.
+func (r *AuditLogRoleAssignmentResource) resolveMembers(ctx context.Context, data AuditLogRoleAssignmentResourceModel, managed map[string]bool) (members, conflicts []string, err error) {
+	edges, err := listRoleEdges(ctx, r.db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users, err := listAuditLogUsers(ctx, r.db, auditLogUserQuery{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grantees := roleGrantees(edges, data.Role.ValueString(), normalizeHostPattern(data.RoleHost.ValueString()))
+	members, conflicts = bulkAssignmentMembers(grantees, users, managed)
+	return members, conflicts, nil
+}
+
+// plannedMembers returns the members in the plan, resolving them now when the
+// plan could not, and stores them in data.
+func (r *AuditLogRoleAssignmentResource) plannedMembers(ctx context.Context, data *AuditLogRoleAssignmentResourceModel, managed map[string]bool) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !data.Members.IsUnknown() && !data.Conflicts.IsUnknown() {
+		var members []string
+		diags.Append(data.Members.ElementsAs(ctx, &members, false)...)
+		return members, diags
+	}
+
+	members, conflicts, err := r.resolveMembers(ctx, *data, managed)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to resolve role members: "+err.Error())
+		return nil, diags
+	}
+
+	data.Members, data.Conflicts, diags = memberLists(ctx, members, conflicts)
+	return members, diags
+}
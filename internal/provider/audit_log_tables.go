@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// auditLogFilterRow is a row of mysql.audit_log_filter.
+type auditLogFilterRow struct {
+	filterID   int64
+	name       string
+	definition string
+}
+
+// auditLogUserRow is a row of mysql.audit_log_user.
+type auditLogUserRow struct {
+	username   string
+	userhost   string
+	filterName string
+}
+
+// auditLogUserQuery narrows listAuditLogUsers. Username and userhost are SQL
+// LIKE patterns; empty fields match every row.
+type auditLogUserQuery struct {
+	username   string
+	userhost   string
+	filterName string
+}
+
+// listAuditLogFilters returns the filters whose name matches the SQL LIKE
+// pattern namePattern, or every filter when it is empty, ordered by name.
+func listAuditLogFilters(ctx context.Context, db *mysqlClient, namePattern string) (filters []auditLogFilterRow, err error) {
+	query := "SELECT filter_id, name, filter FROM mysql.audit_log_filter"
+	var args []any
+	if namePattern != "" {
+		query += " WHERE name LIKE ?"
+		args = append(args, namePattern)
+	}
+
+	rows, err := db.QueryContext(ctx, query+" ORDER BY name", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log filters: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close audit log filter rows: %w", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var row auditLogFilterRow
+		if err := rows.Scan(&row.filterID, &row.name, &row.definition); err != nil {
+			return nil, fmt.Errorf("scan audit log filter: %w", err)
+		}
+		filters = append(filters, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit log filters: %w", err)
+	}
+
+	return filters, nil
+}
+
+// listAuditLogUsers returns the user assignments matching query ordered by
+// account.
+func listAuditLogUsers(ctx context.Context, db *mysqlClient, query auditLogUserQuery) (users []auditLogUserRow, err error) {
+	var conditions []string
+	var args []any
+	if query.username != "" {
+		conditions = append(conditions, "username LIKE ?")
+		args = append(args, query.username)
+	}
+	if query.userhost != "" {
+		conditions = append(conditions, "userhost LIKE ?")
+		args = append(args, query.userhost)
+	}
+	if query.filterName != "" {
+		conditions = append(conditions, "filtername = ?")
+		args = append(args, query.filterName)
+	}
+
+	statement := "SELECT username, userhost, filtername FROM mysql.audit_log_user"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := db.QueryContext(ctx, statement+" ORDER BY username, userhost", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log user assignments: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close audit log user assignment rows: %w", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var row auditLogUserRow
+		if err := rows.Scan(&row.username, &row.userhost, &row.filterName); err != nil {
+			return nil, fmt.Errorf("scan audit log user assignment: %w", err)
+		}
+		users = append(users, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit log user assignments: %w", err)
+	}
+
+	return users, nil
+}
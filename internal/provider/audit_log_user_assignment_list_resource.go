@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &AuditLogUserAssignmentListResource{}
+var _ list.ListResourceWithConfigure = &AuditLogUserAssignmentListResource{}
+
+func NewAuditLogUserAssignmentListResource() list.ListResource {
+	return &AuditLogUserAssignmentListResource{}
+}
+
+// AuditLogUserAssignmentListResource lists the assignments in mysql.audit_log_user.
+type AuditLogUserAssignmentListResource struct {
+	db *mysqlClient
+}
+
+// AuditLogUserAssignmentListResourceModel describes the list block configuration.
+type AuditLogUserAssignmentListResourceModel struct {
+	Username   types.String `tfsdk:"username"`
+	Userhost   types.String `tfsdk:"userhost"`
+	FilterName types.String `tfsdk:"filter_name"`
+}
+
+func (r *AuditLogUserAssignmentListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_assignment"
+}
+
+func (r *AuditLogUserAssignmentListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the audit log user assignments on the server for bulk import.",
+
+		Attributes: map[string]listschema.Attribute{
+			"username": listschema.StringAttribute{
+				Description: "SQL LIKE pattern the username must match, e.g. 'svc_%'. Escape wildcards with a backslash, e.g. '\\%' matches only the '%' default assignment.",
+				Optional:    true,
+			},
+			"userhost": listschema.StringAttribute{
+				Description: "SQL LIKE pattern the host must match.",
+				Optional:    true,
+			},
+			"filter_name": listschema.StringAttribute{
+				Description: "Only list assignments to this filter.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogUserAssignmentListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	db, ok := req.ProviderData.(*mysqlClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.db = db
+}
+
+func (r *AuditLogUserAssignmentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config AuditLogUserAssignmentListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if !checkConnected(&diags, r.db) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	users, err := listAuditLogUsers(ctx, r.db, auditLogUserQuery{
+		username:   config.Username.ValueString(),
+		userhost:   config.Userhost.ValueString(),
+		filterName: config.FilterName.ValueString(),
+	})
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to list user assignments: "+err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, row := range users {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			data := newAuditLogUserAssignmentModel(row)
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = formatAccountName(row.username, row.userhost) + " -> " + row.filterName
+			result.Diagnostics.Append(r.listResult(ctx, req, data, &result)...)
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+func (r *AuditLogUserAssignmentListResource) listResult(ctx context.Context, req list.ListRequest, data AuditLogUserAssignmentResourceModel, result *list.ListResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(result.Identity.Set(ctx, data.identity())...)
+
+	if req.IncludeResource {
+		diags.Append(result.Resource.Set(ctx, &data)...)
+	}
+
+	return diags
+}
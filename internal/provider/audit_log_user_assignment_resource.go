@@ -3,20 +3,28 @@ package provider
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AuditLogUserAssignmentResource{}
 var _ resource.ResourceWithImportState = &AuditLogUserAssignmentResource{}
+var _ resource.ResourceWithModifyPlan = &AuditLogUserAssignmentResource{}
+var _ resource.ResourceWithIdentity = &AuditLogUserAssignmentResource{}
+var _ resource.ResourceWithValidateConfig = &AuditLogUserAssignmentResource{}
 
 func NewAuditLogUserAssignmentResource() resource.Resource {
 	return &AuditLogUserAssignmentResource{}
@@ -24,15 +32,50 @@ func NewAuditLogUserAssignmentResource() resource.Resource {
 
 // AuditLogUserAssignmentResource defines the resource implementation.
 type AuditLogUserAssignmentResource struct {
-	db *sql.DB
+	db *mysqlClient
 }
 
 // AuditLogUserAssignmentResourceModel describes the resource data model.
 type AuditLogUserAssignmentResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Username   types.String `tfsdk:"username"`
-	Userhost   types.String `tfsdk:"userhost"`
-	FilterName types.String `tfsdk:"filter_name"`
+	ID               types.String     `tfsdk:"id"`
+	Username         types.String     `tfsdk:"username"`
+	Userhost         HostPatternValue `tfsdk:"userhost"`
+	FilterName       types.String     `tfsdk:"filter_name"`
+	AllowSelfAbort   types.Bool       `tfsdk:"allow_self_abort"`
+	ExpiresAt        types.String     `tfsdk:"expires_at"`
+	RevertTo         types.String     `tfsdk:"revert_to"`
+	ServerSideExpiry types.Bool       `tfsdk:"server_side_expiry"`
+	ActiveFilterName types.String     `tfsdk:"active_filter_name"`
+	ExpiryEvent      types.String     `tfsdk:"expiry_event"`
+}
+
+// AuditLogUserAssignmentIdentityModel describes the resource identity data model.
+type AuditLogUserAssignmentIdentityModel struct {
+	Username types.String `tfsdk:"username"`
+	Userhost types.String `tfsdk:"userhost"`
+}
+
+// newAuditLogUserAssignmentModel builds the state of an assignment from its mysql.audit_log_user row.
+func newAuditLogUserAssignmentModel(row auditLogUserRow) AuditLogUserAssignmentResourceModel {
+	return AuditLogUserAssignmentResourceModel{
+		ID:               types.StringValue(formatAccountName(row.username, row.userhost)),
+		Username:         types.StringValue(row.username),
+		Userhost:         NewHostPatternValue(row.userhost),
+		FilterName:       types.StringValue(row.filterName),
+		AllowSelfAbort:   types.BoolNull(),
+		ExpiresAt:        types.StringNull(),
+		RevertTo:         types.StringNull(),
+		ServerSideExpiry: types.BoolNull(),
+		ActiveFilterName: types.StringValue(row.filterName),
+		ExpiryEvent:      types.StringNull(),
+	}
+}
+
+func (m AuditLogUserAssignmentResourceModel) identity() AuditLogUserAssignmentIdentityModel {
+	return AuditLogUserAssignmentIdentityModel{
+		Username: m.Username,
+		Userhost: types.StringValue(normalizeHostPattern(m.Userhost.ValueString())),
+	}
 }
 
 func (r *AuditLogUserAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,7 +91,7 @@ func (r *AuditLogUserAssignmentResource) Schema(ctx context.Context, req resourc
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Unique identifier for the user assignment (username@userhost).",
+				Description: "Unique identifier for the user assignment, the quoted account name 'username'@'userhost'.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -62,9 +105,18 @@ func (r *AuditLogUserAssignmentResource) Schema(ctx context.Context, req resourc
 				},
 			},
 			"userhost": schema.StringAttribute{
-				Description: "Host pattern for the user assignment. Use '%' to match any host. " +
+				Description: "Host pattern for the user assignment: a host name or IP address with optional '%' and '_' " +
+					"wildcards, an IPv6 address, or an IPv4 address with a netmask or CIDR prefix such as " +
+					"'10.0.0.0/255.255.255.0' or '10.0.0.0/24'. Host names are compared case-insensitively, as the server " +
+					"lowercases them. Defaults to '%', which matches any host. " +
 					"This is combined with username to form the complete user specification.",
-				Optional: true,
+				Optional:   true,
+				Computed:   true,
+				CustomType: HostPatternType{},
+				Default:    stringdefault.StaticString("%"),
+				Validators: []validator.String{
+					hostPatternValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -73,6 +125,56 @@ func (r *AuditLogUserAssignmentResource) Schema(ctx context.Context, req resourc
 				Description: "Name of the audit log filter to assign to the user. The filter must exist.",
 				Required:    true,
 			},
+			"allow_self_abort": schema.BoolAttribute{
+				Description: "Allow assigning a filter with \"abort\" rules when the assignment applies to the account the " +
+					"provider connects as, either directly or through the '%' default. Both filter_name and revert_to " +
+					"are checked. Defaults to false, which fails the plan to avoid locking the provider out.",
+				Optional: true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp after which the assignment expires, e.g. \"2026-10-18T18:00:00Z\". " +
+					"Once it has passed, the next plan reverts the assignment to revert_to, or removes it when " +
+					"revert_to is not set.",
+				Optional: true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			},
+			"revert_to": schema.StringAttribute{
+				Description: "Name of the filter the assignment reverts to when it expires. Requires expires_at.",
+				Optional:    true,
+			},
+			"server_side_expiry": schema.BoolAttribute{
+				Description: "Also create a MySQL event that performs the revert at expires_at, so it happens even if " +
+					"Terraform does not run. The event is created in the provider's database and requires the " +
+					"event scheduler to be enabled. Requires expires_at.",
+				Optional: true,
+			},
+			"active_filter_name": schema.StringAttribute{
+				Description: "Filter currently assigned by this resource: filter_name until expires_at, then " +
+					"revert_to. Null once an expired assignment without revert_to has been removed.",
+				Computed: true,
+			},
+			"expiry_event": schema.StringAttribute{
+				Description: "Qualified name, schema.event, of the MySQL event created by server_side_expiry. " +
+					"Null when no event is scheduled.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *AuditLogUserAssignmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"username": identityschema.StringAttribute{
+				Description:       "MySQL username of the assignment, or '%' for the default assignment.",
+				RequiredForImport: true,
+			},
+			"userhost": identityschema.StringAttribute{
+				Description:       "Host pattern of the assignment.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -83,12 +185,12 @@ func (r *AuditLogUserAssignmentResource) Configure(ctx context.Context, req reso
 		return
 	}
 
-	db, ok := req.ProviderData.(*sql.DB)
+	db, ok := req.ProviderData.(*mysqlClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sql.DB, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.mysqlClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -97,30 +199,154 @@ func (r *AuditLogUserAssignmentResource) Configure(ctx context.Context, req reso
 	r.db = db
 }
 
-// buildUserSpec constructs the user specification for MySQL functions
-func (r *AuditLogUserAssignmentResource) buildUserSpec(username, userhost string) string {
-	if username == "%" {
-		return "%"
+func (r *AuditLogUserAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AuditLogUserAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || !data.ExpiresAt.IsNull() {
+		return
+	}
+
+	if !data.RevertTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("revert_to"),
+			"Missing Expiry",
+			"revert_to only applies to assignments with expires_at.",
+		)
+	}
+
+	if data.ServerSideExpiry.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server_side_expiry"),
+			"Missing Expiry",
+			"server_side_expiry only applies to assignments with expires_at.",
+		)
+	}
+}
+
+func (r *AuditLogUserAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
 	}
+
+	var plan AuditLogUserAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state *AuditLogUserAssignmentResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &AuditLogUserAssignmentResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The plan shows the assignment reverting once expires_at has passed,
+	// even when the configuration is unchanged.
+	now := time.Now()
+	plan.ActiveFilterName = activeFilterName(plan.FilterName, plan.RevertTo, plan.ExpiresAt, now)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active_filter_name"), plan.ActiveFilterName)...)
+
+	if state == nil && assignmentExpired(plan.ExpiresAt, now) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expires_at"),
+			"Assignment Already Expired",
+			"expires_at has passed, so the assignment is created with its revert_to filter, or not at all.",
+		)
+	}
+
+	plan.ExpiryEvent = types.StringNull()
+	switch {
+	case plan.ServerSideExpiry.IsUnknown() || plan.ExpiresAt.IsUnknown() || plan.RevertTo.IsUnknown():
+		plan.ExpiryEvent = types.StringUnknown()
+	case wantsExpiryEvent(plan, now):
+		plan.ExpiryEvent = types.StringUnknown()
+		if state != nil && !state.ExpiryEvent.IsNull() && state.Username.Equal(plan.Username) &&
+			state.Userhost.Equal(plan.Userhost) && state.ExpiresAt.Equal(plan.ExpiresAt) && state.RevertTo.Equal(plan.RevertTo) {
+			plan.ExpiryEvent = state.ExpiryEvent
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expiry_event"), plan.ExpiryEvent)...)
+
+	// The remaining checks need the database.
+	if r.db == nil {
+		return
+	}
+
+	if plan.ExpiryEvent.IsUnknown() && plan.ServerSideExpiry.ValueBool() {
+		var scheduler string
+		if err := r.db.QueryRowContext(ctx, "SELECT @@GLOBAL.event_scheduler").Scan(&scheduler); err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read event_scheduler: "+err.Error())
+			return
+		}
+		if scheduler != "ON" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("server_side_expiry"),
+				"Event Scheduler Disabled",
+				fmt.Sprintf("event_scheduler is %s, so the expiry event will not run until it is enabled. "+
+					"The next plan after expires_at still reverts the assignment.", scheduler),
+			)
+		}
+	}
+
+	// Filters must exist or be planned by a filter resource earlier in the
+	// run; only changed names are checked.
+	if !plan.FilterName.IsUnknown() && (state == nil || !state.FilterName.Equal(plan.FilterName)) {
+		resp.Diagnostics.Append(checkPlannedFilterExists(ctx, r.db, path.Root("filter_name"), plan.FilterName.ValueString())...)
+	}
+	if !plan.RevertTo.IsNull() && !plan.RevertTo.IsUnknown() && (state == nil || !state.RevertTo.Equal(plan.RevertTo)) {
+		resp.Diagnostics.Append(checkPlannedFilterExists(ctx, r.db, path.Root("revert_to"), plan.RevertTo.ValueString())...)
+	}
+
+	if plan.Username.IsUnknown() || plan.Userhost.IsUnknown() {
+		return
+	}
+
+	username := plan.Username.ValueString()
+	userhost := normalizeHostPattern(plan.Userhost.ValueString())
 	if userhost == "" {
 		userhost = "%"
 	}
-	return fmt.Sprintf("%s@%s", username, userhost)
-}
 
-// parseUserSpec parses a user specification into username and userhost components
-func (r *AuditLogUserAssignmentResource) parseUserSpec(userSpec string) (username, userhost string) {
-	if userSpec == "%" {
-		return "%", ""
+	// A new account must not already have an assignment this resource does
+	// not manage.
+	if state == nil || state.Username.ValueString() != username || normalizeHostPattern(state.Userhost.ValueString()) != userhost {
+		var existingFilter string
+		err := r.db.QueryRowContext(ctx, "SELECT filtername FROM mysql.audit_log_user WHERE username = ? AND userhost = ?", username, userhost).Scan(&existingFilter)
+		switch {
+		case err == nil:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Assignment Already Exists",
+				fmt.Sprintf("%s is already assigned filter '%s' outside this resource. "+
+					"Import the assignment with terraform import, or remove it first.", formatAccountName(username, userhost), existingFilter),
+			)
+		case !errors.Is(err, sql.ErrNoRows):
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing assignment: "+err.Error())
+		}
+	}
+
+	if resp.Diagnostics.HasError() || plan.AllowSelfAbort.ValueBool() {
+		return
+	}
+
+	// The assignment switches to revert_to once it expires, so that filter
+	// must not lock the provider out either.
+	if !plan.FilterName.IsUnknown() {
+		resp.Diagnostics.Append(checkAccountSelfAbort(ctx, r.db, path.Root("filter_name"), username, userhost, plan.FilterName.ValueString())...)
 	}
-	parts := strings.SplitN(userSpec, "@", 2)
-	if len(parts) == 2 {
-		return parts[0], parts[1]
+	if !plan.RevertTo.IsNull() && !plan.RevertTo.IsUnknown() {
+		resp.Diagnostics.Append(checkAccountSelfAbort(ctx, r.db, path.Root("revert_to"), username, userhost, plan.RevertTo.ValueString())...)
 	}
-	return parts[0], "%"
 }
 
 func (r *AuditLogUserAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "create audit log user assignments") {
+		return
+	}
+
 	var data AuditLogUserAssignmentResourceModel
 
 	// Read Terraform plan data into the model
@@ -130,77 +356,92 @@ func (r *AuditLogUserAssignmentResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString(), data.RevertTo.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	// Set default userhost if not provided
-	userhost := data.Userhost.ValueString()
+	userhost := normalizeHostPattern(data.Userhost.ValueString())
 	if userhost == "" {
 		userhost = "%"
-		data.Userhost = types.StringValue(userhost)
+		data.Userhost = NewHostPatternValue(userhost)
 	}
 
 	username := data.Username.ValueString()
-	filterName := data.FilterName.ValueString()
 
-	// Verify the filter exists
-	var filterCount int
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&filterCount)
-	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to check filter existence: "+err.Error())
-		return
+	now := time.Now()
+	if data.ActiveFilterName.IsUnknown() {
+		data.ActiveFilterName = activeFilterName(data.FilterName, data.RevertTo, data.ExpiresAt, now)
 	}
 
-	if filterCount == 0 {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("filter_name"),
-			"Filter Not Found",
-			fmt.Sprintf("No audit log filter found with name '%s'", filterName),
-		)
+	// Verify the filters exist
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), data.FilterName.ValueString())...)
+	if !data.RevertTo.IsNull() {
+		resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("revert_to"), data.RevertTo.ValueString())...)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Check if assignment already exists
 	var existingCount int
-	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_user WHERE username = ? AND userhost = ?", username, userhost).Scan(&existingCount)
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_user WHERE username = ? AND userhost = ?", username, userhost).Scan(&existingCount)
 	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to check existing assignment: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing assignment: "+err.Error())
 		return
 	}
 
 	if existingCount > 0 {
 		resp.Diagnostics.AddError(
 			"Assignment Already Exists",
-			fmt.Sprintf("User assignment already exists for '%s@%s'", username, userhost),
+			fmt.Sprintf("User assignment already exists for %s", formatAccountName(username, userhost)),
 		)
 		return
 	}
 
-	// Create the user assignment using the MySQL function - use direct query due to Go driver issues
-	userSpec := r.buildUserSpec(username, userhost)
-	var result string
-	err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", userSpec, filterName).Scan(&result)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to Create User Assignment",
-			"Could not create audit log user assignment: "+err.Error(),
-		)
-		return
-	}
+	// Create the user assignment using the MySQL function - use direct query due to Go driver issues.
+	// An assignment that expired before it was created and has nothing to
+	// revert to is not created at all.
+	if !data.ActiveFilterName.IsNull() {
+		userSpec := buildUserSpec(username, userhost)
+		var result string
+		err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", userSpec, data.ActiveFilterName.ValueString()).Scan(&result)
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err,
+				"Failed to Create User Assignment",
+				"Could not create audit log user assignment: "+err.Error(),
+			)
+			return
+		}
 
-	if result != "OK" {
-		resp.Diagnostics.AddError(
-			"User Assignment Creation Failed",
-			"MySQL returned an error: "+result,
-		)
-		return
+		if result != "OK" {
+			addMySQLError(&resp.Diagnostics, path.Root("filter_name"), auditResultError(result),
+				"User Assignment Creation Failed",
+				"MySQL returned an error: "+result,
+			)
+			return
+		}
 	}
 
 	// Set computed values
-	data.ID = types.StringValue(fmt.Sprintf("%s@%s", username, userhost))
+	data.ID = types.StringValue(formatAccountName(username, userhost))
+	resp.Diagnostics.Append(r.applyExpiryEvent(ctx, &data, types.StringNull(), now)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *AuditLogUserAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Without a connection, while the provider configuration is unknown,
+	// the prior state is kept.
+	if r.db == nil {
+		return
+	}
+
 	var data AuditLogUserAssignmentResourceModel
 
 	// Read Terraform prior state data into the model
@@ -211,82 +452,135 @@ func (r *AuditLogUserAssignmentResource) Read(ctx context.Context, req resource.
 	}
 
 	username := data.Username.ValueString()
-	userhost := data.Userhost.ValueString()
+	userhost := normalizeHostPattern(data.Userhost.ValueString())
 	if userhost == "" {
 		userhost = "%"
 	}
+	expired := assignmentExpired(data.ExpiresAt, time.Now())
 
 	// Query the user assignment from the database
 	var filterName string
 	err := r.db.QueryRowContext(ctx, "SELECT filtername FROM mysql.audit_log_user WHERE username = ? AND userhost = ?", username, userhost).Scan(&filterName)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// Assignment no longer exists, remove from state
-			resp.State.RemoveResource(ctx)
+	switch {
+	case err == sql.ErrNoRows && expired && data.RevertTo.IsNull():
+		// The expired assignment was removed as planned.
+		data.ActiveFilterName = types.StringNull()
+	case err == sql.ErrNoRows:
+		// Assignment no longer exists, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	case err != nil:
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read user assignment: "+err.Error())
+		return
+	default:
+		// After expiry filter_name keeps its configured value and drift shows
+		// in active_filter_name instead.
+		if !expired {
+			data.FilterName = types.StringValue(filterName)
+		}
+		data.ActiveFilterName = types.StringValue(filterName)
+	}
+
+	// An expiry event drops itself once it has run.
+	if !data.ExpiryEvent.IsNull() {
+		exists, err := expiryEventExists(ctx, r.db, data.ExpiryEvent.ValueString())
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to read expiry event: "+err.Error())
 			return
 		}
-		resp.Diagnostics.AddError("Database Error", "Failed to read user assignment: "+err.Error())
-		return
+		if !exists {
+			data.ExpiryEvent = types.StringNull()
+		}
 	}
 
 	// Update the model with current database values
-	data.FilterName = types.StringValue(filterName)
-	data.Userhost = types.StringValue(userhost)
-	data.ID = types.StringValue(fmt.Sprintf("%s@%s", username, userhost))
+	data.Userhost = NewHostPatternValue(userhost)
+	data.ID = types.StringValue(formatAccountName(username, userhost))
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *AuditLogUserAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AuditLogUserAssignmentResourceModel
+	if r.db.refuseWrite(&resp.Diagnostics, "update audit log user assignments") {
+		return
+	}
 
-	// Read Terraform plan data into the model
+	var data, state AuditLogUserAssignmentResourceModel
+
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false,
+		data.FilterName.ValueString(), data.RevertTo.ValueString(), state.FilterName.ValueString(), state.RevertTo.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	username := data.Username.ValueString()
-	userhost := data.Userhost.ValueString()
+	userhost := normalizeHostPattern(data.Userhost.ValueString())
 	if userhost == "" {
 		userhost = "%"
-		data.Userhost = types.StringValue(userhost)
+		data.Userhost = NewHostPatternValue(userhost)
 	}
-	filterName := data.FilterName.ValueString()
 
-	// Verify the new filter exists
-	var filterCount int
-	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&filterCount)
-	if err != nil {
-		resp.Diagnostics.AddError("Database Error", "Failed to check filter existence: "+err.Error())
-		return
+	now := time.Now()
+	if data.ActiveFilterName.IsUnknown() {
+		data.ActiveFilterName = activeFilterName(data.FilterName, data.RevertTo, data.ExpiresAt, now)
 	}
 
-	if filterCount == 0 {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("filter_name"),
-			"Filter Not Found",
-			fmt.Sprintf("No audit log filter found with name '%s'", filterName),
-		)
+	// Verify the new filters exist
+	resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("filter_name"), data.FilterName.ValueString())...)
+	if !data.RevertTo.IsNull() {
+		resp.Diagnostics.Append(checkFilterExists(ctx, r.db, path.Root("revert_to"), data.RevertTo.ValueString())...)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Update the user assignment using the MySQL function - use direct query
-	userSpec := r.buildUserSpec(username, userhost)
+	userSpec := buildUserSpec(username, userhost)
 	var result string
-	err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", userSpec, filterName).Scan(&result)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to Update User Assignment",
-			"Could not update audit log user assignment: "+err.Error(),
-		)
-		return
+	if data.ActiveFilterName.IsNull() {
+		// The assignment expired without a filter to revert to.
+		exists, err := hasExplicitAssignment(ctx, r.db, username, userhost)
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing assignment: "+err.Error())
+			return
+		}
+		if exists {
+			err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_user(?)", userSpec).Scan(&result)
+		} else {
+			result = "OK"
+		}
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err,
+				"Failed to Update User Assignment",
+				"Could not remove expired audit log user assignment: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		err := r.db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", userSpec, data.ActiveFilterName.ValueString()).Scan(&result)
+		if err != nil {
+			addMySQLError(&resp.Diagnostics, path.Root("filter_name"), err,
+				"Failed to Update User Assignment",
+				"Could not update audit log user assignment: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	if result != "OK" {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Root("filter_name"), auditResultError(result),
 			"User Assignment Update Failed",
 			"MySQL returned an error: "+result,
 		)
@@ -294,13 +588,19 @@ func (r *AuditLogUserAssignmentResource) Update(ctx context.Context, req resourc
 	}
 
 	// Update computed values
-	data.ID = types.StringValue(fmt.Sprintf("%s@%s", username, userhost))
+	data.ID = types.StringValue(formatAccountName(username, userhost))
+	resp.Diagnostics.Append(r.applyExpiryEvent(ctx, &data, state.ExpiryEvent, now)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
 }
 
 func (r *AuditLogUserAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.db.refuseWrite(&resp.Diagnostics, "delete audit log user assignments") {
+		return
+	}
+
 	var data AuditLogUserAssignmentResourceModel
 
 	// Read Terraform prior state data into the model
@@ -310,18 +610,41 @@ func (r *AuditLogUserAssignmentResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
+	locks, ok := lockFilters(ctx, r.db, &resp.Diagnostics, false, data.FilterName.ValueString(), data.RevertTo.ValueString())
+	if !ok {
+		return
+	}
+	defer locks.release(ctx)
+
 	username := data.Username.ValueString()
-	userhost := data.Userhost.ValueString()
+	userhost := normalizeHostPattern(data.Userhost.ValueString())
 	if userhost == "" {
 		userhost = "%"
 	}
 
+	if !data.ExpiryEvent.IsNull() {
+		if err := dropExpiryEvent(ctx, r.db, data.ExpiryEvent.ValueString()); err != nil {
+			addMySQLError(&resp.Diagnostics, path.Empty(), err, "Failed to Delete User Assignment", "Could not "+err.Error())
+			return
+		}
+	}
+
+	// An expired assignment may already have been removed.
+	exists, err := hasExplicitAssignment(ctx, r.db, username, userhost)
+	if err != nil {
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to check existing assignment: "+err.Error())
+		return
+	}
+	if !exists {
+		return
+	}
+
 	// Remove the user assignment using the MySQL function - use direct query
-	userSpec := r.buildUserSpec(username, userhost)
+	userSpec := buildUserSpec(username, userhost)
 	var result string
-	err := r.db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_user(?)", userSpec).Scan(&result)
+	err = r.db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_user(?)", userSpec).Scan(&result)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), err,
 			"Failed to Delete User Assignment",
 			"Could not delete audit log user assignment: "+err.Error(),
 		)
@@ -329,7 +652,7 @@ func (r *AuditLogUserAssignmentResource) Delete(ctx context.Context, req resourc
 	}
 
 	if result != "OK" {
-		resp.Diagnostics.AddError(
+		addMySQLError(&resp.Diagnostics, path.Empty(), auditResultError(result),
 			"User Assignment Deletion Failed",
 			"MySQL returned an error: "+result,
 		)
@@ -337,10 +660,61 @@ func (r *AuditLogUserAssignmentResource) Delete(ctx context.Context, req resourc
 	}
 }
 
+// wantsExpiryEvent reports whether an assignment needs a scheduled expiry
+// event: server_side_expiry is set and expires_at is still ahead.
+func wantsExpiryEvent(data AuditLogUserAssignmentResourceModel, now time.Time) bool {
+	return data.ServerSideExpiry.ValueBool() && !data.ExpiresAt.IsNull() && !assignmentExpired(data.ExpiresAt, now)
+}
+
+// applyExpiryEvent replaces the previous expiry event when the plan left
+// expiry_event unknown, and drops it when the plan no longer wants one.
+func (r *AuditLogUserAssignmentResource) applyExpiryEvent(ctx context.Context, data *AuditLogUserAssignmentResourceModel, previous types.String, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.ExpiryEvent.IsUnknown() && data.ExpiryEvent.Equal(previous) {
+		return diags
+	}
+
+	if !previous.IsNull() {
+		if err := dropExpiryEvent(ctx, r.db, previous.ValueString()); err != nil {
+			addMySQLError(&diags, path.Empty(), err, "Failed to Schedule Assignment Expiry", "Could not "+err.Error())
+			return diags
+		}
+	}
+
+	if !data.ExpiryEvent.IsUnknown() || !wantsExpiryEvent(*data, now) {
+		data.ExpiryEvent = types.StringNull()
+		return diags
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("expires_at"), "Invalid Timestamp", err.Error())
+		return diags
+	}
+
+	event, err := createExpiryEvent(ctx, r.db, data.Username.ValueString(), normalizeHostPattern(data.Userhost.ValueString()),
+		data.RevertTo.ValueString(), expiresAt)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Failed to Schedule Assignment Expiry", "Could not "+err.Error())
+		return diags
+	}
+
+	data.ExpiryEvent = types.StringValue(event)
+	return diags
+}
+
 func (r *AuditLogUserAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by user specification (username@userhost)
-	userSpec := req.ID
-	username, userhost := r.parseUserSpec(userSpec)
+	if !checkConnected(&resp.Diagnostics, r.db) {
+		return
+	}
+
+	username, userhost, diags := r.accountFromImport(ctx, req)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Validate that the assignment exists
 	var filterName string
@@ -349,26 +723,46 @@ func (r *AuditLogUserAssignmentResource) ImportState(ctx context.Context, req re
 		if err == sql.ErrNoRows {
 			resp.Diagnostics.AddError(
 				"User Assignment Not Found",
-				fmt.Sprintf("No user assignment found for '%s'", userSpec),
+				fmt.Sprintf("No user assignment found for %s", formatAccountName(username, userhost)),
 			)
 			return
 		}
-		resp.Diagnostics.AddError("Database Error", "Failed to query user assignment: "+err.Error())
+		addMySQLError(&resp.Diagnostics, path.Empty(), err, "Database Error", "Failed to query user assignment: "+err.Error())
 		return
 	}
 
-	// Set the state
-	data := AuditLogUserAssignmentResourceModel{
-		ID:       types.StringValue(userSpec),
-		Username: types.StringValue(username),
-		Userhost: types.StringValue(func() string {
-			if userhost == "" {
-				return "%"
-			}
-			return userhost
-		}()),
-		FilterName: types.StringValue(filterName),
+	if userhost == "" {
+		userhost = "%"
 	}
 
+	// Set the state
+	data := newAuditLogUserAssignmentModel(auditLogUserRow{
+		username:   username,
+		userhost:   userhost,
+		filterName: filterName,
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, data.identity())...)
+}
+
+// accountFromImport returns the account of an imported assignment. Import IDs
+// are parsed with parseAccountName; import blocks that use
+// identity = { username = ..., userhost = ... } need no parsing.
+func (r *AuditLogUserAssignmentResource) accountFromImport(ctx context.Context, req resource.ImportStateRequest) (username, userhost string, diags diag.Diagnostics) {
+	if req.ID != "" {
+		username, userhost, err := parseAccountName(req.ID)
+		userhost = normalizeHostPattern(userhost)
+		if err != nil {
+			diags.AddError(
+				"Invalid Import ID",
+				"Expected an account name such as 'user'@'host', user@host or %: "+err.Error(),
+			)
+		}
+		return username, userhost, diags
+	}
+
+	var identity AuditLogUserAssignmentIdentityModel
+	diags.Append(req.Identity.Get(ctx, &identity)...)
+	return identity.Username.ValueString(), normalizeHostPattern(identity.Userhost.ValueString()), diags
 }
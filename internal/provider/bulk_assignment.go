@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Bulk assignments manage one mysql.audit_log_user row per account in a
+// computed set of accounts. Accounts are tracked as quoted account names in
+// the members list of the resource's state.
+
+// bulkAssignmentMembers splits the accounts a bulk assignment selects into the
+// accounts it manages and the conflicts it leaves alone: accounts that already
+// have an explicit row in mysql.audit_log_user which the bulk assignment does
+// not manage. Managed holds the quoted names of the accounts it assigned
+// earlier. Both results are quoted account names.
+func bulkAssignmentMembers(accounts []mysqlAccount, users []auditLogUserRow, managed map[string]bool) (members, conflicts []string) {
+	assigned := make(map[string]bool, len(users))
+	for _, row := range users {
+		assigned[formatAccountName(row.username, row.userhost)] = true
+	}
+
+	members = []string{}
+	conflicts = []string{}
+	for _, account := range accounts {
+		name := formatAccountName(account.username, account.userhost)
+		if assigned[name] && !managed[name] {
+			conflicts = append(conflicts, name)
+			continue
+		}
+		members = append(members, name)
+	}
+
+	return members, conflicts
+}
+
+// memberLists converts members and conflicts to list values. A nil slice
+// becomes an empty list rather than null.
+func memberLists(ctx context.Context, members, conflicts []string) (types.List, types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if members == nil {
+		members = []string{}
+	}
+	if conflicts == nil {
+		conflicts = []string{}
+	}
+
+	membersList, d := types.ListValueFrom(ctx, types.StringType, members)
+	diags.Append(d...)
+	conflictsList, d := types.ListValueFrom(ctx, types.StringType, conflicts)
+	diags.Append(d...)
+
+	return membersList, conflictsList, diags
+}
+
+func addMembers(ctx context.Context, set map[string]bool, list types.List) diag.Diagnostics {
+	var members []string
+	diags := list.ElementsAs(ctx, &members, false)
+	for _, member := range members {
+		set[member] = true
+	}
+	return diags
+}
+
+func sortedMembers(set map[string]bool) []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// assignedMembers returns the members that are still assigned filterName.
+// Members whose assignment was removed or changed outside Terraform drop out,
+// so the next plan either assigns them again or reports a conflict.
+func assignedMembers(ctx context.Context, db *mysqlClient, members []string, filterName string) ([]string, error) {
+	users, err := listAuditLogUsers(ctx, db, auditLogUserQuery{filterName: filterName})
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := make(map[string]bool, len(users))
+	for _, row := range users {
+		assigned[formatAccountName(row.username, row.userhost)] = true
+	}
+
+	result := []string{}
+	for _, member := range members {
+		if assigned[member] {
+			result = append(result, member)
+		}
+	}
+	return result, nil
+}
+
+// assignMembers assigns filterName to every member and returns the members
+// assigned before the first failure.
+func assignMembers(ctx context.Context, db *mysqlClient, members []string, filterName string) ([]string, error) {
+	assigned := make([]string, 0, len(members))
+	for _, member := range members {
+		if err := setAccountFilter(ctx, db, member, filterName); err != nil {
+			return assigned, fmt.Errorf("assign filter '%s' to %s: %w", filterName, member, err)
+		}
+		assigned = append(assigned, member)
+	}
+	return assigned, nil
+}
+
+// removeMembers removes the assignments of members that still point to
+// filterName.
+func removeMembers(ctx context.Context, db *mysqlClient, members []string, filterName string) error {
+	for _, member := range members {
+		if err := removeAccountFilter(ctx, db, member, filterName); err != nil {
+			return fmt.Errorf("remove the assignment of %s: %w", member, err)
+		}
+	}
+	return nil
+}
+
+// setAccountFilter assigns a filter to an account given as a quoted account
+// name.
+func setAccountFilter(ctx context.Context, db *mysqlClient, account, filterName string) error {
+	username, userhost, err := parseAccountName(account)
+	if err != nil {
+		return err
+	}
+
+	var result string
+	if err := db.QueryRowContext(ctx, "SELECT audit_log_filter_set_user(?, ?)", buildUserSpec(username, userhost), filterName).Scan(&result); err != nil {
+		return err
+	}
+	if result != "OK" {
+		return auditResultError(result)
+	}
+	return nil
+}
+
+// removeAccountFilter removes the assignment of an account given as a quoted
+// account name, unless it no longer exists or now assigns a filter other than
+// filterName, in which case it belongs to someone else.
+func removeAccountFilter(ctx context.Context, db *mysqlClient, account, filterName string) error {
+	username, userhost, err := parseAccountName(account)
+	if err != nil {
+		return err
+	}
+
+	var current string
+	err = db.QueryRowContext(ctx, "SELECT filtername FROM mysql.audit_log_user WHERE username = ? AND userhost = ?", username, userhost).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current != filterName {
+		return nil
+	}
+
+	var result string
+	if err := db.QueryRowContext(ctx, "SELECT audit_log_filter_remove_user(?)", buildUserSpec(username, userhost)).Scan(&result); err != nil {
+		return err
+	}
+	if result != "OK" {
+		return auditResultError(result)
+	}
+	return nil
+}
+
+// filterExists reports whether a filter named filterName exists.
+func filterExists(ctx context.Context, db *mysqlClient, filterName string) (bool, error) {
+	var filterCount int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&filterCount)
+	return filterCount > 0, err
+}
+
+// checkFilterExists reports an error on attribute when filterName does not
+// exist.
+func checkFilterExists(ctx context.Context, db *mysqlClient, attribute path.Path, filterName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	exists, err := filterExists(ctx, db, filterName)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to check filter existence: "+err.Error())
+		return diags
+	}
+
+	if !exists {
+		diags.AddAttributeError(
+			attribute,
+			"Filter Not Found",
+			fmt.Sprintf("No audit log filter found with name '%s'", filterName),
+		)
+	}
+
+	return diags
+}
+
+// checkPlannedFilterExists is checkFilterExists for ModifyPlan: filters that
+// a filter resource plans to create in the same run count as existing.
+func checkPlannedFilterExists(ctx context.Context, db *mysqlClient, attribute path.Path, filterName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if db.filterPlanned(filterName) {
+		return diags
+	}
+
+	exists, err := filterExists(ctx, db, filterName)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to check filter existence: "+err.Error())
+		return diags
+	}
+
+	if !exists {
+		diags.AddAttributeError(
+			attribute,
+			"Filter Not Found",
+			fmt.Sprintf("No audit log filter named '%s' exists on the server or is planned by an auditlogfilters_filter resource. "+
+				"Create the filter, or refer to the name of its auditlogfilters_filter resource so that it is planned first.", filterName),
+		)
+	}
+
+	return diags
+}
+
+// checkMembersSelfAbort fails when filterName contains "abort" rules and the
+// account the provider connects as is one of the members. A filter planned
+// in the same run is checked against its planned definition.
+func checkMembersSelfAbort(ctx context.Context, db *mysqlClient, members []string, filterName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	aborts, err := filterAborts(ctx, db, filterName)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to read filter definition: "+err.Error())
+		return diags
+	}
+	if !aborts {
+		return diags
+	}
+
+	currentUser, currentHost, err := queryCurrentAccount(ctx, db)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to determine the provider account: "+err.Error())
+		return diags
+	}
+
+	currentAccount := formatAccountName(currentUser, currentHost)
+	for _, member := range members {
+		if member == currentAccount {
+			diags.AddAttributeError(
+				path.Root("filter_name"),
+				"Filter Would Abort Provider Account",
+				selfAbortDetail(currentUser, currentHost, filterName),
+			)
+			break
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBulkAssignmentMembers(t *testing.T) {
+	t.Parallel()
+
+	grantees := []mysqlAccount{
+		{username: "alice", userhost: "%"},
+		{username: "bob", userhost: "%"},
+		{username: "carol", userhost: "%"},
+	}
+	users := []auditLogUserRow{
+		{username: "alice", userhost: "%", filterName: "pii"},
+		{username: "bob", userhost: "%", filterName: "other"},
+		{username: "%", userhost: "", filterName: "default"},
+	}
+
+	cases := []struct {
+		name          string
+		managed       map[string]bool
+		wantMembers   []string
+		wantConflicts []string
+	}{
+		{
+			name:          "existing assignments conflict",
+			managed:       map[string]bool{},
+			wantMembers:   []string{`'carol'@'%'`},
+			wantConflicts: []string{`'alice'@'%'`, `'bob'@'%'`},
+		},
+		{
+			name:          "managed assignments stay members",
+			managed:       map[string]bool{`'alice'@'%'`: true},
+			wantMembers:   []string{`'alice'@'%'`, `'carol'@'%'`},
+			wantConflicts: []string{`'bob'@'%'`},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			members, conflicts := bulkAssignmentMembers(grantees, users, tc.managed)
+			if !reflect.DeepEqual(members, tc.wantMembers) {
+				t.Fatalf("members = %v, want %v", members, tc.wantMembers)
+			}
+			if !reflect.DeepEqual(conflicts, tc.wantConflicts) {
+				t.Fatalf("conflicts = %v, want %v", conflicts, tc.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestCheckMembersSelfAbortPlannedFilter(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(&fakeServer{currentUser: "terraform@%"})
+	filterResp := modifyPlan(t, &AuditLogFilterResource{db: client}, map[string]tftypes.Value{
+		"name":       tftypes.NewValue(tftypes.String, "block_deletes"),
+		"definition": tftypes.NewValue(tftypes.String, `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`),
+	})
+	if filterResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected filter plan diagnostics: %+v", filterResp.Diagnostics)
+	}
+
+	diags := checkMembersSelfAbort(context.Background(), client, []string{"'app'@'%'", "'terraform'@'%'"}, "block_deletes")
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Filter Would Abort Provider Account" {
+		t.Fatalf("expected self-abort error for a filter planned in the same run, got: %+v", diags)
+	}
+
+	diags = checkMembersSelfAbort(context.Background(), client, []string{"'app'@'%'"}, "block_deletes")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics without the provider account: %+v", diags)
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DescribeDefinitionFunction{}
+
+func NewDescribeDefinitionFunction() function.Function {
+	return &DescribeDefinitionFunction{}
+}
+
+// DescribeDefinitionFunction defines the describe_definition provider function.
+type DescribeDefinitionFunction struct{}
+
+func (f *DescribeDefinitionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "describe_definition"
+}
+
+func (f *DescribeDefinitionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render an audit log filter definition as readable text",
+		MarkdownDescription: "Returns a human-readable description of an audit log filter definition, listing the event " +
+			"classes and subclasses that are logged or aborted and the conditions that apply, e.g. " +
+			"`Logs connection events (connect, disconnect); aborts nothing.` Structurally invalid definitions " +
+			"cause the function to fail.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "definition",
+				Description: "JSON audit log filter definition.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DescribeDefinitionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var definition string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &definition))
+	if resp.Error != nil {
+		return
+	}
+
+	summary, err := describeAuditLogFilterDefinition(definition)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, summary))
+}
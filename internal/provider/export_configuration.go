@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Files written by ExportConfiguration.
+const (
+	ExportFiltersFile     = "filters.tf"
+	ExportAssignmentsFile = "user_assignments.tf"
+	ExportImportsFile     = "imports.tf"
+)
+
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportConfiguration connects with the provider's environment configuration
+// (the MYSQL_* variables) and generates Terraform configuration for every
+// audit log filter and user assignment on the server, together with matching
+// import blocks. The result maps file names to their contents.
+func ExportConfiguration(ctx context.Context) (map[string][]byte, error) {
+	var diagnostics diag.Diagnostics
+
+	validated, ok := parseAndValidateProviderConfig(loadRawConfig(AuditLogFilterProviderModel{}), &diagnostics)
+	if !ok {
+		return nil, diagnosticsError(diagnostics)
+	}
+
+	client := newMySQLClient(validated)
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if _, err := client.connect(ctx); err != nil {
+		var connErr *connectionError
+		if errors.As(err, &connErr) {
+			return nil, diagnosticsError(connErr.diags)
+		}
+		return nil, err
+	}
+
+	filters, err := listAuditLogFilters(ctx, client, "")
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := listAuditLogUsers(ctx, client, auditLogUserQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderExportedConfiguration(filters, users)
+}
+
+func renderExportedConfiguration(filters []auditLogFilterRow, users []auditLogUserRow) (map[string][]byte, error) {
+	filterFile := hclwrite.NewEmptyFile()
+	assignmentFile := hclwrite.NewEmptyFile()
+	importFile := hclwrite.NewEmptyFile()
+
+	usedNames := map[string]bool{}
+	filterResources := map[string]string{}
+
+	for i, filter := range filters {
+		resourceName := uniqueResourceName(filter.name, "filter", usedNames)
+		filterResources[filter.name] = resourceName
+
+		definition, err := definitionTokens(filter.definition)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", filter.name, err)
+		}
+
+		if i > 0 {
+			filterFile.Body().AppendNewline()
+		}
+		block := filterFile.Body().AppendNewBlock("resource", []string{"auditlogfilters_filter", resourceName})
+		block.Body().SetAttributeValue("name", cty.StringVal(filter.name))
+		block.Body().SetAttributeRaw("definition", definition)
+
+		appendImportBlock(importFile.Body(), "auditlogfilters_filter", resourceName, filter.name)
+	}
+
+	for i, user := range users {
+		account := user.username + "_" + user.userhost
+		if user.username == "%" {
+			account = "default"
+		}
+		resourceName := uniqueResourceName(account, "assignment", usedNames)
+
+		if i > 0 {
+			assignmentFile.Body().AppendNewline()
+		}
+		block := assignmentFile.Body().AppendNewBlock("resource", []string{"auditlogfilters_user_assignment", resourceName})
+		block.Body().SetAttributeValue("username", cty.StringVal(user.username))
+		block.Body().SetAttributeValue("userhost", cty.StringVal(user.userhost))
+		if filterResource, ok := filterResources[user.filterName]; ok {
+			block.Body().SetAttributeTraversal("filter_name", hcl.Traversal{
+				hcl.TraverseRoot{Name: "auditlogfilters_filter"},
+				hcl.TraverseAttr{Name: filterResource},
+				hcl.TraverseAttr{Name: "name"},
+			})
+		} else {
+			block.Body().SetAttributeValue("filter_name", cty.StringVal(user.filterName))
+		}
+
+		appendImportBlock(importFile.Body(), "auditlogfilters_user_assignment", resourceName,
+			formatAccountName(user.username, user.userhost))
+	}
+
+	return map[string][]byte{
+		ExportFiltersFile:     hclwrite.Format(filterFile.Bytes()),
+		ExportAssignmentsFile: hclwrite.Format(assignmentFile.Bytes()),
+		ExportImportsFile:     hclwrite.Format(importFile.Bytes()),
+	}, nil
+}
+
+func appendImportBlock(body *hclwrite.Body, resourceType, resourceName, id string) {
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock("import", nil)
+	block.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: resourceName},
+	})
+	block.Body().SetAttributeValue("id", cty.StringVal(id))
+}
+
+// definitionTokens renders a stored JSON definition as a jsonencode() call
+// with an HCL object literal, which reads better in review than a JSON string.
+func definitionTokens(definition string) (hclwrite.Tokens, error) {
+	normalized, err := normalizeJSON(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	impliedType, err := ctyjson.ImpliedType([]byte(normalized))
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ctyjson.Unmarshal([]byte(normalized), impliedType)
+	if err != nil {
+		return nil, err
+	}
+
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(value)), nil
+}
+
+// uniqueResourceName turns an arbitrary name into a Terraform resource name
+// that has not been used yet in this export.
+func uniqueResourceName(name, fallback string, used map[string]bool) string {
+	base := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" {
+		base = fallback
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = fallback + "_" + base
+	}
+
+	candidate := base
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// diagnosticsError flattens error diagnostics into a Go error for callers
+// outside of the Terraform plugin protocol.
+func diagnosticsError(diagnostics diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diagnostics.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	if len(errs) == 0 {
+		return errors.New("unknown error")
+	}
+	return errors.Join(errs...)
+}
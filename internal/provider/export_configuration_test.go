@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderExportedConfiguration(t *testing.T) {
+	t.Parallel()
+
+	files, err := renderExportedConfiguration(
+		[]auditLogFilterRow{
+			{filterID: 1, name: "log-all", definition: `{"filter":{"log":true}}`},
+			{filterID: 2, name: "Log All", definition: `{"filter":{"class":[{"name":"connection"}]}}`},
+		},
+		[]auditLogUserRow{
+			{username: "%", userhost: "", filterName: "log-all"},
+			{username: "app", userhost: "localhost", filterName: "Log All"},
+			{username: "ops", userhost: "%", filterName: "unmanaged"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("renderExportedConfiguration() error = %v", err)
+	}
+
+	filters := string(files[ExportFiltersFile])
+	for _, want := range []string{
+		`resource "auditlogfilters_filter" "log_all" {`,
+		`resource "auditlogfilters_filter" "log_all_2" {`,
+		`name = "Log All"`,
+		`definition = jsonencode({`,
+		`name = "connection"`,
+	} {
+		if !strings.Contains(filters, want) {
+			t.Fatalf("filters.tf missing %q:\n%s", want, filters)
+		}
+	}
+
+	assignments := string(files[ExportAssignmentsFile])
+	for _, want := range []string{
+		`resource "auditlogfilters_user_assignment" "default" {`,
+		`filter_name = auditlogfilters_filter.log_all.name`,
+		`resource "auditlogfilters_user_assignment" "app_localhost" {`,
+		`filter_name = auditlogfilters_filter.log_all_2.name`,
+		`filter_name = "unmanaged"`,
+	} {
+		if !strings.Contains(assignments, want) {
+			t.Fatalf("user_assignments.tf missing %q:\n%s", want, assignments)
+		}
+	}
+
+	imports := string(files[ExportImportsFile])
+	for _, want := range []string{
+		`to = auditlogfilters_filter.log_all_2`,
+		`id = "Log All"`,
+		`to = auditlogfilters_user_assignment.app_localhost`,
+		`id = "'app'@'localhost'"`,
+	} {
+		if !strings.Contains(imports, want) {
+			t.Fatalf("imports.tf missing %q:\n%s", want, imports)
+		}
+	}
+}
+
+func TestUniqueResourceName(t *testing.T) {
+	t.Parallel()
+
+	used := map[string]bool{}
+	cases := []struct {
+		name string
+		want string
+	}{
+		{name: "audit-all", want: "audit_all"},
+		{name: "Audit All", want: "audit_all_2"},
+		{name: "2024 policy", want: "filter_2024_policy"},
+		{name: "***", want: "filter"},
+	}
+
+	for _, tc := range cases {
+		if got := uniqueResourceName(tc.name, "filter", used); got != tc.want {
+			t.Fatalf("uniqueResourceName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeServer answers the read queries that plan-time checks run, from
+// filters and assignments held in memory.
+type fakeServer struct {
+	currentUser string
+	filters     map[string]string
+	assignments map[[2]string]string
+}
+
+// newFakeClient returns a connected client backed by server.
+func newFakeClient(server *fakeServer) *mysqlClient {
+	client := newMySQLClient(providerValidatedConfig{})
+	client.connected = true
+	client.db = sql.OpenDB(fakeConnector{server: server})
+	return client
+}
+
+func (s *fakeServer) query(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error) {
+	arg := func(i int) string { return fmt.Sprint(args[i].Value) }
+
+	switch query {
+	case currentUserQuery:
+		return []string{"CURRENT_USER()"}, [][]driver.Value{{s.currentUser}}, nil
+	case "SELECT COUNT(*) FROM mysql.audit_log_filter WHERE name = ?":
+		_, ok := s.filters[arg(0)]
+		return []string{"COUNT(*)"}, [][]driver.Value{{boolCount(ok)}}, nil
+	case "SELECT filter FROM mysql.audit_log_filter WHERE name = ?":
+		if definition, ok := s.filters[arg(0)]; ok {
+			return []string{"filter"}, [][]driver.Value{{definition}}, nil
+		}
+		return []string{"filter"}, nil, nil
+	case "SELECT filtername FROM mysql.audit_log_user WHERE username = ? AND userhost = ?":
+		if filterName, ok := s.assignments[[2]string{arg(0), arg(1)}]; ok {
+			return []string{"filtername"}, [][]driver.Value{{filterName}}, nil
+		}
+		return []string{"filtername"}, nil, nil
+	case "SELECT COUNT(*) FROM mysql.audit_log_user WHERE username = ? AND userhost = ?":
+		_, ok := s.assignments[[2]string{arg(0), arg(1)}]
+		return []string{"COUNT(*)"}, [][]driver.Value{{boolCount(ok)}}, nil
+	case "SELECT username, userhost, filtername FROM mysql.audit_log_user WHERE username = '%' ORDER BY userhost LIMIT 1":
+		if filterName, ok := s.assignments[[2]string{"%", "%"}]; ok {
+			return []string{"username", "userhost", "filtername"}, [][]driver.Value{{"%", "%", filterName}}, nil
+		}
+		return []string{"username", "userhost", "filtername"}, nil, nil
+	}
+
+	return nil, nil, fmt.Errorf("fake server: unexpected query %q", query)
+}
+
+func boolCount(ok bool) int64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+type fakeConnector struct {
+	server *fakeServer
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return fakeConn(c), nil
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake server: open by name is not supported")
+}
+
+type fakeConn struct {
+	server *fakeServer
+}
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake server: prepared statements are not supported")
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake server: transactions are not supported")
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, values, err := c.server.query(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: columns, values: values}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// resourceSchema returns the schema of r.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %+v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// modifyPlan runs the ModifyPlan of a resource being created with the given
+// planned attribute values; other attributes are null.
+func modifyPlan(t *testing.T, r resource.ResourceWithModifyPlan, values map[string]tftypes.Value) resource.ModifyPlanResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	s := resourceSchema(t, r)
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(objectType, attributes)}
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: plan.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+	return resp
+}
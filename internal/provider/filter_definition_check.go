@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Severities reported by CheckDefinition.
+const (
+	DefinitionSeverityError   = "error"
+	DefinitionSeverityWarning = "warning"
+)
+
+// DefinitionProblem is a validation error or lint warning found when checking
+// a filter definition outside of Terraform, e.g. by the validate subcommand.
+type DefinitionProblem struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// CheckDefinition validates and normalizes a definition the same way the
+// auditlogfilters_filter resource does, then lints it. The normalized
+// definition is returned when no errors were found.
+func CheckDefinition(definition []byte) (string, []DefinitionProblem) {
+	if err := validateAuditLogFilterDefinition(string(definition)); err != nil {
+		problem := DefinitionProblem{
+			Severity: DefinitionSeverityError,
+			Path:     "$",
+			Message:  err.Error(),
+		}
+
+		var pathErr *definitionPathError
+		if errors.As(err, &pathErr) {
+			problem.Path = pathErr.path
+		}
+
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			problem.Line, problem.Column = offsetPosition(definition, syntaxErr.Offset)
+		}
+
+		return "", []DefinitionProblem{problem}
+	}
+
+	normalized, err := normalizeJSON(string(definition))
+	if err != nil {
+		return "", []DefinitionProblem{{
+			Severity: DefinitionSeverityError,
+			Path:     "$",
+			Message:  "the filter definition could not be normalized: " + err.Error(),
+		}}
+	}
+
+	findings, err := lintAuditLogFilterDefinition(normalized)
+	if err != nil {
+		return normalized, nil
+	}
+
+	problems := make([]DefinitionProblem, 0, len(findings))
+	for _, finding := range findings {
+		problems = append(problems, DefinitionProblem{
+			Severity: DefinitionSeverityWarning,
+			Path:     finding.Path,
+			Message:  finding.Message,
+		})
+	}
+
+	return normalized, problems
+}
+
+// offsetPosition converts a byte offset reported by encoding/json into a
+// 1-based line and column.
+func offsetPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
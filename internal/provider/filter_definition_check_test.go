@@ -0,0 +1,69 @@
+package provider
+
+import "testing"
+
+func TestCheckDefinition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		definition     string
+		wantNormalized string
+		wantSeverity   string
+		wantPath       string
+		wantLine       int
+	}{
+		{
+			name:           "valid definition is normalized",
+			definition:     "{\n  \"filter\": {\"class\": {\"name\": \"connection\"}}\n}",
+			wantNormalized: `{"filter":{"class":{"name":"connection"}}}`,
+		},
+		{
+			name:         "syntax error has a position",
+			definition:   "{\n  \"filter\": {\"class\": }\n}",
+			wantSeverity: DefinitionSeverityError,
+			wantPath:     "$",
+			wantLine:     2,
+		},
+		{
+			name:         "structural error has a JSON path",
+			definition:   `{"filter":{"class":{"name":"table_access","log":{"and":[]}}}}`,
+			wantSeverity: DefinitionSeverityError,
+			wantPath:     "$.filter.class.log.and",
+		},
+		{
+			name:           "lint finding is a warning",
+			definition:     `{"filter":{"class":{"name":"table_access","abort":true}}}`,
+			wantNormalized: `{"filter":{"class":{"abort":true,"name":"table_access"}}}`,
+			wantSeverity:   DefinitionSeverityWarning,
+			wantPath:       "$.filter.class.abort",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			normalized, problems := CheckDefinition([]byte(tc.definition))
+			if normalized != tc.wantNormalized {
+				t.Fatalf("unexpected normalized definition: got %q, want %q", normalized, tc.wantNormalized)
+			}
+			if tc.wantSeverity == "" {
+				if len(problems) != 0 {
+					t.Fatalf("expected no problems, got: %+v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 {
+				t.Fatalf("expected one problem, got: %+v", problems)
+			}
+			if problems[0].Severity != tc.wantSeverity || problems[0].Path != tc.wantPath {
+				t.Fatalf("unexpected problem: %+v", problems[0])
+			}
+			if tc.wantLine != 0 && problems[0].Line != tc.wantLine {
+				t.Fatalf("unexpected line: got %d, want %d", problems[0].Line, tc.wantLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// definitionEventClasses are the event classes the audit_log_filter component
+// generates. A filter that logs everything captures all of them.
+var definitionEventClasses = []string{"connection", "general", "message", "query", "table_access"}
+
+// definitionLoggedClasses evaluates a filter definition offline and returns
+// the sorted event classes for which it logs at least some events. Classes
+// logged only under a condition count as logged; classes excluded with
+// "log": false on the class item do not.
+func definitionLoggedClasses(definition string) ([]string, error) {
+	if err := validateAuditLogFilterDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal([]byte(definition), &root); err != nil {
+		return nil, err
+	}
+
+	filter, _ := root[definitionRootKey].(map[string]any)
+	classes := describeItems(filter["class"])
+
+	logAll := len(classes) == 0
+	if value, ok := filter["log"].(bool); ok {
+		logAll = value
+	}
+
+	logged := map[string]bool{}
+	if logAll {
+		for _, class := range definitionEventClasses {
+			logged[class] = true
+		}
+	}
+
+	for _, class := range classes {
+		names := describeNames(class["name"])
+		classLogged := itemLogs(class)
+
+		events := describeItems(class["event"])
+		if len(events) > 0 && !logAll {
+			classLogged = false
+			for _, event := range events {
+				if itemLogs(event) {
+					classLogged = true
+					break
+				}
+			}
+		}
+
+		for _, name := range names {
+			switch {
+			case logAll && len(events) == 0 && !classLogged:
+				delete(logged, name)
+			case classLogged:
+				logged[name] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(logged))
+	for class := range logged {
+		result = append(result, class)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// itemLogs reports whether a class or event item logs matching events: it
+// does unless its "log" value is false.
+func itemLogs(item map[string]any) bool {
+	return !isFalse(item["log"])
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefinitionLoggedClasses(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		definition string
+		want       []string
+	}{
+		{
+			name:       "log all",
+			definition: `{"filter":{"log":true}}`,
+			want:       []string{"connection", "general", "message", "query", "table_access"},
+		},
+		{
+			name:       "log nothing",
+			definition: `{"filter":{"log":false}}`,
+			want:       []string{},
+		},
+		{
+			name:       "single class",
+			definition: `{"filter":{"class":{"name":"connection"}}}`,
+			want:       []string{"connection"},
+		},
+		{
+			name:       "class list",
+			definition: `{"filter":{"class":[{"name":"table_access"},{"name":["connection","general"]}]}}`,
+			want:       []string{"connection", "general", "table_access"},
+		},
+		{
+			name:       "conditional class counts as logged",
+			definition: `{"filter":{"class":{"name":"table_access","log":{"field":{"name":"table_database.str","value":"pii"}}}}}`,
+			want:       []string{"table_access"},
+		},
+		{
+			name:       "class excluded from log all",
+			definition: `{"filter":{"log":true,"class":{"name":"general","log":false}}}`,
+			want:       []string{"connection", "message", "query", "table_access"},
+		},
+		{
+			name:       "events all excluded",
+			definition: `{"filter":{"class":{"name":"connection","event":[{"name":"connect","log":false}]}}}`,
+			want:       []string{},
+		},
+		{
+			name:       "some events logged",
+			definition: `{"filter":{"class":{"name":"connection","event":[{"name":"connect","log":false},{"name":"disconnect"}]}}}`,
+			want:       []string{"connection"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := definitionLoggedClasses(tc.definition)
+			if err != nil {
+				t.Fatalf("definitionLoggedClasses() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("definitionLoggedClasses() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := definitionLoggedClasses(`{"class":{}}`); err == nil {
+		t.Fatalf("expected error for invalid definition")
+	}
+}
+
+func TestAuditLogCoverage(t *testing.T) {
+	t.Parallel()
+
+	data := auditLogCoverage(
+		[]mysqlAccount{
+			{username: "admin", userhost: "localhost", privileged: true},
+			{username: "app", userhost: "%"},
+			{username: "mysql.sys", userhost: "localhost", locked: true},
+		},
+		[]auditLogUserRow{
+			{username: "admin", userhost: "localhost", filterName: "full"},
+			{username: "%", userhost: "", filterName: "connections"},
+		},
+		[]auditLogFilterRow{
+			{name: "full", definition: `{"filter":{"log":true}}`},
+			{name: "connections", definition: `{"filter":{"class":{"name":"connection"}}}`},
+		},
+	)
+
+	if len(data.Accounts) != 3 {
+		t.Fatalf("expected 3 accounts, got %d", len(data.Accounts))
+	}
+
+	admin := data.Accounts[0]
+	if admin.Status.ValueString() != coverageExplicit || admin.FilterName.ValueString() != "full" || len(admin.LoggedClasses) != len(definitionEventClasses) {
+		t.Fatalf("unexpected admin coverage: %+v", admin)
+	}
+	if !admin.Privileged.ValueBool() {
+		t.Fatalf("expected admin to be privileged")
+	}
+
+	app := data.Accounts[1]
+	if app.Status.ValueString() != coverageDefault || app.FilterName.ValueString() != "connections" {
+		t.Fatalf("unexpected app coverage: %+v", app)
+	}
+	if len(app.LoggedClasses) != 1 || app.LoggedClasses[0].ValueString() != "connection" {
+		t.Fatalf("unexpected app logged classes: %v", app.LoggedClasses)
+	}
+
+	if len(data.UnauditedAccounts) != 0 {
+		t.Fatalf("expected every account to be covered, got %v", data.UnauditedAccounts)
+	}
+
+	uncovered := auditLogCoverage(
+		[]mysqlAccount{{username: "app", userhost: "%"}},
+		nil,
+		nil,
+	)
+	if uncovered.Accounts[0].Status.ValueString() != coverageUnaudited || !uncovered.Accounts[0].FilterName.IsNull() {
+		t.Fatalf("unexpected unaudited coverage: %+v", uncovered.Accounts[0])
+	}
+	if len(uncovered.UnauditedAccounts) != 1 || uncovered.UnauditedAccounts[0].ValueString() != `'app'@'%'` {
+		t.Fatalf("unexpected unaudited accounts: %v", uncovered.UnauditedAccounts)
+	}
+}
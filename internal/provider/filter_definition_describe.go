@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// definitionDescription collects the logged, excluded and aborted rules of a
+// definition as readable phrases.
+type definitionDescription struct {
+	logs     []string
+	excludes []string
+	aborts   []string
+}
+
+// describeAuditLogFilterDefinition renders a filter definition as readable
+// text, e.g. "Logs connection events (connect, disconnect); aborts nothing."
+// It walks conditions with the same operator rules as validateConditionObject.
+func describeAuditLogFilterDefinition(definition string) (string, error) {
+	if err := validateAuditLogFilterDefinition(definition); err != nil {
+		return "", err
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal([]byte(definition), &root); err != nil {
+		return "", err
+	}
+
+	filter, _ := root["filter"].(map[string]any)
+	classes := describeItems(filter["class"])
+
+	logAll := len(classes) == 0
+	if value, ok := filter["log"].(bool); ok {
+		logAll = value
+	}
+
+	var description definitionDescription
+	for _, class := range classes {
+		if err := description.addClass(class, logAll); err != nil {
+			return "", err
+		}
+	}
+
+	var sb strings.Builder
+	switch {
+	case logAll && len(description.excludes) > 0:
+		sb.WriteString("Logs all events except " + joinPhrases(description.excludes))
+	case logAll:
+		sb.WriteString("Logs all events")
+	case len(description.logs) > 0:
+		sb.WriteString("Logs " + joinPhrases(description.logs))
+	default:
+		sb.WriteString("Logs nothing")
+	}
+
+	if len(description.aborts) > 0 {
+		sb.WriteString("; aborts " + joinPhrases(description.aborts) + ".")
+	} else {
+		sb.WriteString("; aborts nothing.")
+	}
+
+	return sb.String(), nil
+}
+
+func (d *definitionDescription) addClass(class map[string]any, logAll bool) error {
+	names := describeNames(class["name"])
+	subject := strings.Join(names, " and ") + " events"
+
+	events := describeItems(class["event"])
+	if len(events) == 0 {
+		return d.addRule(subject, class, logAll)
+	}
+
+	for _, event := range events {
+		eventSubject := subject
+		if eventNames := describeNames(event["name"]); len(eventNames) > 0 {
+			eventSubject = fmt.Sprintf("%s (%s)", subject, strings.Join(eventNames, ", "))
+		}
+		if err := d.addRule(eventSubject, event, logAll); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRule records the log and abort behaviour of a class or event item.
+// Items are logged unless they say otherwise, except inside a filter that
+// already logs everything, where only "log": false changes the outcome.
+func (d *definitionDescription) addRule(subject string, item map[string]any, logAll bool) error {
+	switch logValue := item["log"].(type) {
+	case bool:
+		if !logValue {
+			if logAll {
+				d.excludes = append(d.excludes, subject)
+			}
+		} else if !logAll {
+			d.logs = append(d.logs, subject)
+		}
+	case map[string]any:
+		condition, err := describeCondition(logValue, "$")
+		if err != nil {
+			return err
+		}
+		if logAll {
+			d.excludes = append(d.excludes, subject+" unless "+condition)
+		} else {
+			d.logs = append(d.logs, subject+" when "+condition)
+		}
+	case nil:
+		if !logAll {
+			d.logs = append(d.logs, subject)
+		}
+	}
+
+	switch abortValue := item["abort"].(type) {
+	case bool:
+		if abortValue {
+			d.aborts = append(d.aborts, subject)
+		}
+	case map[string]any:
+		condition, err := describeCondition(abortValue, "$")
+		if err != nil {
+			return err
+		}
+		d.aborts = append(d.aborts, subject+" when "+condition)
+	}
+
+	return nil
+}
+
+// describeCondition renders a logical condition. Nested and/or operators are
+// parenthesised so that the rendered precedence matches the JSON structure.
+func describeCondition(object map[string]any, path string) (string, error) {
+	rule, err := conditionOperator(object, path)
+	if err != nil {
+		return "", err
+	}
+	if rule == nil {
+		return canonicalJSON(object), nil
+	}
+
+	switch operator := rule.name; operator {
+	case "and", "or":
+		expressions, _ := object[operator].([]any)
+		parts := make([]string, 0, len(expressions))
+		for i, expression := range expressions {
+			nested, _ := expression.(map[string]any)
+			part, err := describeCondition(nested, fmt.Sprintf("%s.%s[%d]", path, operator, i))
+			if err != nil {
+				return "", err
+			}
+			if nestedRule, _ := conditionOperator(nested, path); len(expressions) > 1 &&
+				nestedRule != nil && nestedRule.operand == operandConditionList {
+				part = "(" + part + ")"
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, " "+operator+" "), nil
+	case "not":
+		nested, _ := object["not"].(map[string]any)
+		part, err := describeCondition(nested, path+".not")
+		if err != nil {
+			return "", err
+		}
+		return "not (" + part + ")", nil
+	case "field":
+		field, _ := object["field"].(map[string]any)
+		return describeField(field), nil
+	}
+
+	return canonicalJSON(object), nil
+}
+
+func describeField(field map[string]any) string {
+	name, _ := field["name"].(string)
+	value, ok := field["value"]
+	if !ok {
+		return name
+	}
+	if text, ok := value.(string); ok {
+		return fmt.Sprintf("%s = %q", name, text)
+	}
+	return fmt.Sprintf("%s = %s", name, canonicalJSON(value))
+}
+
+func describeItems(value any) []map[string]any {
+	switch typed := value.(type) {
+	case map[string]any:
+		return []map[string]any{typed}
+	case []any:
+		items := make([]map[string]any, 0, len(typed))
+		for _, entry := range typed {
+			if object, ok := entry.(map[string]any); ok {
+				items = append(items, object)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+func describeNames(value any) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case []any:
+		names := make([]string, 0, len(typed))
+		for _, entry := range typed {
+			if name, ok := entry.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// joinPhrases joins phrases as "a, b and c" after removing duplicates.
+func joinPhrases(phrases []string) string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, phrase := range phrases {
+		if !seen[phrase] {
+			seen[phrase] = true
+			unique = append(unique, phrase)
+		}
+	}
+
+	switch len(unique) {
+	case 0:
+		return ""
+	case 1:
+		return unique[0]
+	}
+	return strings.Join(unique[:len(unique)-1], ", ") + " and " + unique[len(unique)-1]
+}
+
+// definitionSummary returns the description of a definition for the computed
+// summary attribute, or null when the definition cannot be described.
+func definitionSummary(definition string) types.String {
+	summary, err := describeAuditLogFilterDefinition(definition)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(summary)
+}
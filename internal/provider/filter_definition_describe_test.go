@@ -0,0 +1,75 @@
+package provider
+
+import "testing"
+
+func TestDescribeAuditLogFilterDefinition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		definition string
+		want       string
+	}{
+		{
+			name:       "log everything",
+			definition: `{"filter":{"log":true}}`,
+			want:       "Logs all events; aborts nothing.",
+		},
+		{
+			name:       "log nothing",
+			definition: `{"filter":{"log":false}}`,
+			want:       "Logs nothing; aborts nothing.",
+		},
+		{
+			name: "connection and table access reads",
+			definition: `{"filter":{"class":[
+				{"name":"connection","event":{"name":["connect","disconnect"]}},
+				{"name":"table_access","event":{"name":"read","log":{"field":{"name":"table_database.str","value":"db1"}}}}
+			]}}`,
+			want: `Logs connection events (connect, disconnect) and table_access events (read) when table_database.str = "db1"; aborts nothing.`,
+		},
+		{
+			name: "nested conditions and abort",
+			definition: `{"filter":{"class":{"name":"table_access","event":[
+				{"name":"read","log":{"and":[
+					{"field":{"name":"table_database.str","value":"prod"}},
+					{"or":[
+						{"field":{"name":"table_name.str","value":"employee"}},
+						{"not":{"field":{"name":"table_name.str","value":"projects"}}}
+					]}
+				]}},
+				{"name":"delete","abort":true}
+			]}}}`,
+			want: `Logs table_access events (read) when table_database.str = "prod" and (table_name.str = "employee" or not (table_name.str = "projects")) ` +
+				`and table_access events (delete); aborts table_access events (delete).`,
+		},
+		{
+			name:       "log everything except a class",
+			definition: `{"filter":{"log":true,"class":{"name":"general","log":false}}}`,
+			want:       "Logs all events except general events; aborts nothing.",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := describeAuditLogFilterDefinition(tc.definition)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected description:\n got: %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefinitionSummaryInvalid(t *testing.T) {
+	t.Parallel()
+
+	if got := definitionSummary(`{"class":{"name":"connection"}}`); !got.IsNull() {
+		t.Fatalf("expected null summary for an invalid definition, got %q", got.ValueString())
+	}
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"encoding/json"
+)
+
+// definitionRootKey is the top-level key every filter definition must contain.
+const definitionRootKey = "filter"
+
+// conditionOperandKind describes what a logical operator accepts as operand.
+type conditionOperandKind int
+
+const (
+	// operandConditionList is a non-empty array of condition objects.
+	operandConditionList conditionOperandKind = iota
+	// operandCondition is a single condition object.
+	operandCondition
+	// operandObject is an object whose contents are not checked further.
+	operandObject
+)
+
+// conditionOperatorRule is one logical operator of the audit log filter
+// condition grammar. The validator and the JSON Schema are both generated
+// from conditionOperatorRules so they cannot diverge.
+type conditionOperatorRule struct {
+	name        string
+	operand     conditionOperandKind
+	description string
+}
+
+var conditionOperatorRules = []conditionOperatorRule{
+	{
+		name:        "and",
+		operand:     operandConditionList,
+		description: "True when every condition in the array is true.",
+	},
+	{
+		name:        "or",
+		operand:     operandConditionList,
+		description: "True when at least one condition in the array is true.",
+	},
+	{
+		name:        "not",
+		operand:     operandCondition,
+		description: "True when the nested condition is false.",
+	},
+	{
+		name:        "field",
+		operand:     operandObject,
+		description: "Compares an event field, e.g. {\"name\": \"table_database.str\", \"value\": \"prod\"}.",
+	},
+}
+
+// definitionKeywords documents the well-known keys of filter, class and event
+// items. They only add descriptions to the JSON Schema; the validator places
+// no constraints on them.
+var definitionKeywords = []struct {
+	name        string
+	description string
+}{
+	{name: "class", description: "Event class rule, or an array of rules, e.g. {\"name\": \"connection\"}."},
+	{name: "event", description: "Event subclass rule, or an array of rules, within a class."},
+	{name: "name", description: "Class or event subclass name, or an array of names."},
+	{name: "log", description: "true, false, or a condition object deciding whether matching events are logged."},
+	{name: "abort", description: "true, false, or a condition object deciding whether matching statements are blocked."},
+	{name: "print", description: "Replaces field values in logged events."},
+}
+
+// auditLogFilterDefinitionSchema builds a JSON Schema (draft 2020-12) document
+// describing the structure enforced by validateAuditLogFilterDefinition.
+func auditLogFilterDefinitionSchema() map[string]any {
+	operatorRequirements := make([]any, 0, len(conditionOperatorRules))
+	for _, rule := range conditionOperatorRules {
+		operatorRequirements = append(operatorRequirements, map[string]any{"required": []any{rule.name}})
+	}
+
+	keywordProperties := map[string]any{}
+	for _, keyword := range definitionKeywords {
+		keywordProperties[keyword.name] = map[string]any{
+			"description": keyword.description,
+			"$ref":        "#/$defs/node",
+		}
+	}
+
+	objectVariants := []any{map[string]any{"$ref": "#/$defs/container"}}
+	defs := map[string]any{
+		"node": map[string]any{
+			"description": "Any JSON value. Objects are checked as containers or condition objects.",
+			"if":          map[string]any{"type": "object"},
+			"then":        map[string]any{"$ref": "#/$defs/object"},
+			"else": map[string]any{
+				"if":   map[string]any{"type": "array"},
+				"then": map[string]any{"items": map[string]any{"$ref": "#/$defs/node"}},
+			},
+		},
+		"container": map[string]any{
+			"description":          "An object without logical operators, such as a filter, class or event item.",
+			"type":                 "object",
+			"not":                  map[string]any{"anyOf": operatorRequirements},
+			"properties":           keywordProperties,
+			"additionalProperties": map[string]any{"$ref": "#/$defs/node"},
+		},
+	}
+
+	for _, rule := range conditionOperatorRules {
+		var others []any
+		for _, other := range conditionOperatorRules {
+			if other.name != rule.name {
+				others = append(others, map[string]any{"required": []any{other.name}})
+			}
+		}
+
+		defName := "condition_" + rule.name
+		defs[defName] = map[string]any{
+			"description": rule.description,
+			"type":        "object",
+			"required":    []any{rule.name},
+			"not":         map[string]any{"anyOf": others},
+			"properties": map[string]any{
+				rule.name: operandSchema(rule.operand),
+			},
+		}
+		objectVariants = append(objectVariants, map[string]any{"$ref": "#/$defs/" + defName})
+	}
+
+	defs["object"] = map[string]any{
+		"description": "A container object, or a condition object with exactly one logical operator.",
+		"oneOf":       objectVariants,
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         "https://registry.terraform.io/providers/0ch1r/auditlogfilters/filter-definition.schema.json",
+		"title":       "Audit log filter definition",
+		"description": "Definition accepted by audit_log_filter_set_filter() and the auditlogfilters_filter resource.",
+		"type":        "object",
+		"required":    []any{definitionRootKey},
+		"properties": map[string]any{
+			definitionRootKey: map[string]any{
+				"description": "Root of the filter rules.",
+				"type":        "object",
+			},
+		},
+		"allOf": []any{map[string]any{"$ref": "#/$defs/object"}},
+		"$defs": defs,
+	}
+}
+
+func operandSchema(kind conditionOperandKind) map[string]any {
+	switch kind {
+	case operandConditionList:
+		return map[string]any{
+			"type":     "array",
+			"minItems": 1,
+			"items": map[string]any{
+				"type": "object",
+				"$ref": "#/$defs/object",
+			},
+		}
+	case operandCondition:
+		return map[string]any{
+			"type": "object",
+			"$ref": "#/$defs/object",
+		}
+	default:
+		return map[string]any{
+			"type": "object",
+		}
+	}
+}
+
+// DefinitionJSONSchema returns the JSON Schema for audit log filter
+// definitions as an indented JSON document.
+func DefinitionJSONSchema() ([]byte, error) {
+	return json.MarshalIndent(auditLogFilterDefinitionSchema(), "", "  ")
+}
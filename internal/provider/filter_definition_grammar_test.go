@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogFilterDefinitionSchemaCoversGrammar(t *testing.T) {
+	t.Parallel()
+
+	document, err := DefinitionJSONSchema()
+	if err != nil {
+		t.Fatalf("unexpected error generating schema: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(document, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	required, _ := schema["required"].([]any)
+	if len(required) != 1 || required[0] != definitionRootKey {
+		t.Fatalf("expected schema to require %q, got %v", definitionRootKey, required)
+	}
+
+	defs, _ := schema["$defs"].(map[string]any)
+	for _, rule := range conditionOperatorRules {
+		def, ok := defs["condition_"+rule.name].(map[string]any)
+		if !ok {
+			t.Fatalf("schema is missing a definition for operator %q", rule.name)
+		}
+		properties, _ := def["properties"].(map[string]any)
+		operand, _ := properties[rule.name].(map[string]any)
+		wantType := "object"
+		if rule.operand == operandConditionList {
+			wantType = "array"
+		}
+		if operand["type"] != wantType {
+			t.Fatalf("operator %q operand type: got %v, want %s", rule.name, operand["type"], wantType)
+		}
+	}
+}
+
+// TestValidatorFollowsGrammar checks that every operand rule in the grammar is
+// enforced by validateAuditLogFilterDefinition.
+func TestValidatorFollowsGrammar(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(condition string) string {
+		return fmt.Sprintf(`{"filter":{"class":{"name":"connection","log":%s}}}`, condition)
+	}
+	field := `{"field":{"name":"user.str","value":"app"}}`
+
+	for _, rule := range conditionOperatorRules {
+		var valid, invalid []string
+		switch rule.operand {
+		case operandConditionList:
+			valid = []string{fmt.Sprintf(`{%q:[%s]}`, rule.name, field)}
+			invalid = []string{fmt.Sprintf(`{%q:[]}`, rule.name), fmt.Sprintf(`{%q:%s}`, rule.name, field), fmt.Sprintf(`{%q:["x"]}`, rule.name)}
+		case operandCondition:
+			valid = []string{fmt.Sprintf(`{%q:%s}`, rule.name, field)}
+			invalid = []string{fmt.Sprintf(`{%q:[%s]}`, rule.name, field), fmt.Sprintf(`{%q:"x"}`, rule.name)}
+		case operandObject:
+			valid = []string{fmt.Sprintf(`{%q:{"name":"user.str","value":"app"}}`, rule.name)}
+			invalid = []string{fmt.Sprintf(`{%q:"x"}`, rule.name)}
+		}
+
+		for _, condition := range valid {
+			if err := validateAuditLogFilterDefinition(wrap(condition)); err != nil {
+				t.Fatalf("operator %q: expected %s to be valid, got: %v", rule.name, condition, err)
+			}
+		}
+		for _, condition := range invalid {
+			if err := validateAuditLogFilterDefinition(wrap(condition)); err == nil {
+				t.Fatalf("operator %q: expected %s to be rejected", rule.name, condition)
+			}
+		}
+	}
+}
+
+// TestDefinitionJSONSchemaValidatesDefinitions evaluates the generated JSON
+// Schema against known-good and known-bad definitions, and checks that it
+// agrees with validateAuditLogFilterDefinition on each of them.
+func TestDefinitionJSONSchemaValidatesDefinitions(t *testing.T) {
+	t.Parallel()
+
+	document, err := DefinitionJSONSchema()
+	if err != nil {
+		t.Fatalf("unexpected error generating schema: %v", err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(document, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		definition string
+		want       bool
+	}{
+		{name: "log_all", definition: `{"filter":{"log":true}}`, want: true},
+		{name: "empty_filter", definition: `{"filter":{}}`, want: true},
+		{name: "class_names", definition: `{"filter":{"class":[{"name":"connection"},{"name":"general"}]}}`, want: true},
+		{
+			name:       "nested_conditions",
+			definition: `{"filter":{"class":{"name":"table_access","event":{"name":["insert","update"],"log":{"and":[{"field":{"name":"table_database.str","value":"prod"}},{"not":{"or":[{"field":{"name":"table_name.str","value":"a"}},{"field":{"name":"table_name.str","value":"b"}}]}}]}}}}}`,
+			want:       true,
+		},
+		{name: "abort_condition", definition: `{"filter":{"class":{"name":"query","event":{"name":"start","abort":{"field":{"name":"sql_command_id","value":"drop_db"}}}}}}`, want: true},
+		{name: "not_an_object", definition: `["filter"]`},
+		{name: "missing_filter", definition: `{"class":{"name":"connection"}}`},
+		{name: "filter_not_object", definition: `{"filter":true}`},
+		{name: "empty_and", definition: `{"filter":{"log":{"and":[]}}}`},
+		{name: "or_not_array", definition: `{"filter":{"log":{"or":{"field":{"name":"user.str","value":"app"}}}}}`},
+		{name: "or_item_not_object", definition: `{"filter":{"log":{"or":["x"]}}}`},
+		{name: "not_array", definition: `{"filter":{"log":{"not":[{"field":{"name":"user.str","value":"app"}}]}}}`},
+		{name: "field_not_object", definition: `{"filter":{"log":{"field":"user.str"}}}`},
+		{name: "two_operators", definition: `{"filter":{"log":{"not":{"field":{"name":"user.str","value":"app"}},"field":{"name":"user.str","value":"app"}}}}`},
+		{name: "bad_nested_in_array", definition: `{"filter":{"class":[{"name":"connection","log":{"and":[]}}]}}`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var value any
+			if err := json.Unmarshal([]byte(tc.definition), &value); err != nil {
+				t.Fatalf("test definition is not valid JSON: %v", err)
+			}
+			if got := schemaAccepts(schema, schema, value); got != tc.want {
+				t.Fatalf("schema accepts %s = %t, want %t", tc.definition, got, tc.want)
+			}
+			if got := validateAuditLogFilterDefinition(tc.definition) == nil; got != tc.want {
+				t.Fatalf("validator accepts %s = %t, want %t", tc.definition, got, tc.want)
+			}
+		})
+	}
+}
+
+// schemaAccepts reports whether value is valid against schema. It evaluates
+// the JSON Schema keywords the generated definition schema uses, resolving
+// $ref against the $defs of root.
+func schemaAccepts(root, schema map[string]any, value any) bool {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, _ := root["$defs"].(map[string]any)[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if target == nil || !schemaAccepts(root, target, value) {
+			return false
+		}
+	}
+
+	object, isObject := value.(map[string]any)
+	array, isArray := value.([]any)
+
+	if wantType, ok := schema["type"].(string); ok {
+		switch wantType {
+		case "object":
+			if !isObject {
+				return false
+			}
+		case "array":
+			if !isArray {
+				return false
+			}
+		}
+	}
+
+	if isObject {
+		for _, name := range schemaList(schema["required"]) {
+			if _, ok := object[name.(string)]; !ok {
+				return false
+			}
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for name, member := range object {
+			if property, ok := properties[name].(map[string]any); ok {
+				if !schemaAccepts(root, property, member) {
+					return false
+				}
+			} else if additional != nil && !schemaAccepts(root, additional, member) {
+				return false
+			}
+		}
+	}
+
+	if isArray {
+		if minItems, ok := schema["minItems"].(float64); ok && float64(len(array)) < minItems {
+			return false
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for _, item := range array {
+				if !schemaAccepts(root, items, item) {
+					return false
+				}
+			}
+		}
+	}
+
+	for _, sub := range schemaList(schema["allOf"]) {
+		if !schemaAccepts(root, sub.(map[string]any), value) {
+			return false
+		}
+	}
+	if anyOf := schemaList(schema["anyOf"]); anyOf != nil {
+		if countAccepting(root, anyOf, value) == 0 {
+			return false
+		}
+	}
+	if oneOf := schemaList(schema["oneOf"]); oneOf != nil {
+		if countAccepting(root, oneOf, value) != 1 {
+			return false
+		}
+	}
+	if not, ok := schema["not"].(map[string]any); ok && schemaAccepts(root, not, value) {
+		return false
+	}
+	if condition, ok := schema["if"].(map[string]any); ok {
+		branch, _ := schema["else"].(map[string]any)
+		if schemaAccepts(root, condition, value) {
+			branch, _ = schema["then"].(map[string]any)
+		}
+		if branch != nil && !schemaAccepts(root, branch, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func schemaList(value any) []any {
+	list, _ := value.([]any)
+	return list
+}
+
+func countAccepting(root map[string]any, schemas []any, value any) int {
+	count := 0
+	for _, sub := range schemas {
+		if schemaAccepts(root, sub.(map[string]any), value) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// definitionLintFinding is a logical problem found in an otherwise valid
+// filter definition. Findings are reported as warnings, never as errors.
+type definitionLintFinding struct {
+	Path    string `tfsdk:"path"`
+	Message string `tfsdk:"message"`
+}
+
+type definitionLinter struct {
+	findings []definitionLintFinding
+}
+
+// lintAuditLogFilterDefinition reports conditions that are always true or
+// always false, duplicated "or" branches and "abort" rules that match every
+// event. The definition must pass validateAuditLogFilterDefinition first.
+func lintAuditLogFilterDefinition(definition string) ([]definitionLintFinding, error) {
+	if err := validateAuditLogFilterDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal([]byte(definition), &root); err != nil {
+		return nil, err
+	}
+
+	linter := &definitionLinter{}
+	if filter, ok := root["filter"].(map[string]any); ok {
+		linter.lintRuleScope(filter, "$.filter", false)
+	}
+
+	return linter.findings, nil
+}
+
+func (l *definitionLinter) addFinding(path, format string, args ...any) {
+	l.findings = append(l.findings, definitionLintFinding{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// lintRuleScope lints the log/abort rules of a filter, class or event item.
+// narrowed reports whether the scope is restricted to named event subclasses.
+func (l *definitionLinter) lintRuleScope(object map[string]any, path string, narrowed bool) {
+	if condition, ok := object["log"].(map[string]any); ok {
+		l.lintCondition(condition, path+".log")
+	}
+
+	// An abort that is always true gets one finding: outside a named-event
+	// scope the broader scope message replaces the condition message.
+	if abort, ok := object["abort"]; ok {
+		always, condition := false, false
+		switch typed := abort.(type) {
+		case bool:
+			always = typed
+		case map[string]any:
+			value, known := l.lintCondition(typed, path+".abort")
+			always, condition = known && value, true
+		}
+		switch {
+		case always && !narrowed:
+			l.addFinding(path+".abort", "abort rule matches every event in this scope; restrict it to named events or add a condition")
+		case always && condition:
+			l.addFinding(path+".abort", "abort condition is always true, so every matching event is blocked")
+		}
+	}
+
+	for _, key := range []string{"class", "event"} {
+		switch typed := object[key].(type) {
+		case map[string]any:
+			l.lintRuleScope(typed, path+"."+key, namesEvents(key, typed))
+		case []any:
+			for i, entry := range typed {
+				if item, ok := entry.(map[string]any); ok {
+					l.lintRuleScope(item, fmt.Sprintf("%s.%s[%d]", path, key, i), namesEvents(key, item))
+				}
+			}
+		}
+	}
+}
+
+// lintCondition walks a logical condition and returns its constant value when
+// it can be determined without evaluating any event fields.
+func (l *definitionLinter) lintCondition(condition map[string]any, path string) (value bool, known bool) {
+	if children, ok := condition["and"].([]any); ok {
+		return l.lintJunction("and", children, path+".and")
+	}
+	if children, ok := condition["or"].([]any); ok {
+		return l.lintJunction("or", children, path+".or")
+	}
+	if nested, ok := condition["not"].(map[string]any); ok {
+		value, known := l.lintCondition(nested, path+".not")
+		if known && value {
+			l.addFinding(path+".not", "\"not\" wraps a condition that is always true, so this condition never matches")
+		}
+		return !value, known
+	}
+	return false, false
+}
+
+func (l *definitionLinter) lintJunction(operator string, children []any, path string) (bool, bool) {
+	// An "and" is decided by its first false branch, an "or" by its first true one.
+	deciding := operator == "or"
+
+	seen := map[string]int{}
+	allKnown := true
+	decided := false
+
+	for i, child := range children {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		object, ok := child.(map[string]any)
+		if !ok {
+			allKnown = false
+			continue
+		}
+
+		key := canonicalJSON(object)
+		if first, dup := seen[key]; dup && operator == "or" {
+			l.addFinding(childPath, "branch duplicates %s[%d]", path, first)
+		} else if !dup {
+			seen[key] = i
+		}
+
+		value, known := l.lintCondition(object, childPath)
+		if !known {
+			allKnown = false
+			continue
+		}
+		if value == deciding {
+			decided = true
+		}
+	}
+
+	if operator == "and" {
+		if conflict := conflictingFieldEqualities(children); conflict != "" {
+			l.addFinding(path, "condition is always false: %s", conflict)
+			return false, true
+		}
+	}
+
+	if complement := complementaryBranches(children); complement {
+		if operator == "and" {
+			l.addFinding(path, "condition is always false: it requires a branch and its negation")
+		}
+		return deciding, true
+	}
+
+	if decided {
+		return deciding, true
+	}
+	if allKnown {
+		return !deciding, true
+	}
+	return false, false
+}
+
+// conflictingFieldEqualities describes two field conditions in the same "and"
+// that test one field for different values, or returns an empty string.
+func conflictingFieldEqualities(children []any) string {
+	values := map[string]string{}
+	for _, child := range children {
+		object, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		field, ok := object["field"].(map[string]any)
+		if !ok {
+			continue
+		}
+		name, ok := field["name"].(string)
+		if !ok {
+			continue
+		}
+		value, ok := field["value"]
+		if !ok {
+			continue
+		}
+		rendered := canonicalJSON(value)
+		if previous, exists := values[name]; exists && previous != rendered {
+			return fmt.Sprintf("field %q cannot equal both %s and %s", name, previous, rendered)
+		}
+		values[name] = rendered
+	}
+	return ""
+}
+
+// complementaryBranches reports whether the branches contain a condition and
+// its direct negation.
+func complementaryBranches(children []any) bool {
+	present := map[string]bool{}
+	for _, child := range children {
+		present[canonicalJSON(child)] = true
+	}
+	for _, child := range children {
+		object, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		if nested, ok := object["not"]; ok && present[canonicalJSON(nested)] {
+			return true
+		}
+	}
+	return false
+}
+
+// namesEvents reports whether an item restricts its rules to named event
+// subclasses.
+func namesEvents(key string, item map[string]any) bool {
+	_, ok := item["name"]
+	return key == "event" && ok
+}
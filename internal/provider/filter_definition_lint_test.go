@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLintAuditLogFilterDefinition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		definition   string
+		wantPaths    []string
+		wantContains []string
+	}{
+		{
+			name:       "clean definition",
+			definition: `{"filter":{"class":{"name":"table_access","event":{"name":"read","log":{"field":{"name":"table_database.str","value":"pii"}}}}}}`,
+		},
+		{
+			name: "and of different values for one field is always false",
+			definition: `{"filter":{"class":{"name":"table_access","event":{"name":"read","log":{"and":[
+				{"field":{"name":"table_name.str","value":"employee"}},
+				{"field":{"name":"table_name.str","value":"projects"}}
+			]}}}}}`,
+			wantPaths:    []string{"$.filter.class.event.log.and"},
+			wantContains: []string{"always false"},
+		},
+		{
+			name: "duplicate or branches",
+			definition: `{"filter":{"class":[{"name":"table_access","event":{"name":"read","log":{"or":[
+				{"field":{"name":"table_name.str","value":"employee"}},
+				{"field":{"name":"table_name.str","value":"employee"}}
+			]}}}]}}`,
+			wantPaths:    []string{"$.filter.class[0].event.log.or[1]"},
+			wantContains: []string{"duplicates $.filter.class[0].event.log.or[0]"},
+		},
+		{
+			name: "not wrapping an always true condition",
+			definition: `{"filter":{"class":{"name":"connection","log":{"not":{"or":[
+				{"field":{"name":"user.str","value":"app"}},
+				{"not":{"field":{"name":"user.str","value":"app"}}}
+			]}}}}}`,
+			wantPaths:    []string{"$.filter.class.log.not"},
+			wantContains: []string{"never matches"},
+		},
+		{
+			name:         "abort matching every event of a class",
+			definition:   `{"filter":{"class":{"name":"table_access","abort":true}}}`,
+			wantPaths:    []string{"$.filter.class.abort"},
+			wantContains: []string{"matches every event"},
+		},
+		{
+			name: "always true abort condition outside named events",
+			definition: `{"filter":{"class":{"name":"table_access","abort":{"or":[
+				{"field":{"name":"user.str","value":"app"}},
+				{"not":{"field":{"name":"user.str","value":"app"}}}
+			]}}}}`,
+			wantPaths:    []string{"$.filter.class.abort"},
+			wantContains: []string{"matches every event in this scope"},
+		},
+		{
+			name: "always true abort condition on named events",
+			definition: `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":{"or":[
+				{"field":{"name":"user.str","value":"app"}},
+				{"not":{"field":{"name":"user.str","value":"app"}}}
+			]}}}}}`,
+			wantPaths:    []string{"$.filter.class.event.abort"},
+			wantContains: []string{"abort condition is always true"},
+		},
+		{
+			name:       "abort restricted to named events",
+			definition: `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings, err := lintAuditLogFilterDefinition(tc.definition)
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if len(findings) != len(tc.wantPaths) {
+				t.Fatalf("expected %d findings, got %d: %+v", len(tc.wantPaths), len(findings), findings)
+			}
+			for i, finding := range findings {
+				if finding.Path != tc.wantPaths[i] {
+					t.Fatalf("unexpected finding path: got %q, want %q", finding.Path, tc.wantPaths[i])
+				}
+				if !strings.Contains(finding.Message, tc.wantContains[i]) {
+					t.Fatalf("expected finding message to contain %q, got: %q", tc.wantContains[i], finding.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLintAuditLogFilterDefinitionInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := lintAuditLogFilterDefinition(`{"class":{"name":"connection"}}`); err == nil {
+		t.Fatalf("expected structurally invalid definition to return an error")
+	}
+}
+
+func TestLintDefinitionFunctionRun(t *testing.T) {
+	t.Parallel()
+
+	definition := `{"filter":{"class":{"name":"table_access","abort":true}}}`
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(definition)}),
+	}
+	resp := &function.RunResponse{
+		Result: function.NewResultData(types.ListUnknown(definitionLintFindingType)),
+	}
+
+	NewLintDefinitionFunction().Run(context.Background(), req, resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected function error: %v", resp.Error)
+	}
+
+	result, ok := resp.Result.Value().(types.List)
+	if !ok {
+		t.Fatalf("expected list result, got %T", resp.Result.Value())
+	}
+	if len(result.Elements()) != 1 {
+		t.Fatalf("expected one finding, got %d", len(result.Elements()))
+	}
+}
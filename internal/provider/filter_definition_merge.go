@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// mergeFragment is a parsed definition fragment reduced to the parts that
+// take part in merging: the top-level log default and the expanded class rules.
+type mergeFragment struct {
+	index   int
+	source  string
+	log     bool
+	classes []mergeClassItem
+}
+
+// mergeClassItem is a single class rule with its name split out. Class items
+// that list several names are expanded into one item per name.
+type mergeClassItem struct {
+	fragment int
+	path     string
+	name     string
+	body     map[string]any
+}
+
+// mergeEventRule accumulates the log conditions that apply to one event
+// subclass across all fragments. A nil condition means unconditional logging.
+type mergeEventRule struct {
+	name          string
+	unconditional bool
+	conditions    []any
+}
+
+func (f mergeFragment) logsNothing() bool {
+	return !f.log && len(f.classes) == 0
+}
+
+func (f mergeFragment) logsEverything() bool {
+	return f.log && len(f.classes) == 0
+}
+
+// mergeAuditLogFilterDefinitions combines several filter definitions into one
+// definition that logs an event whenever any of the fragments would log it.
+// Class and event rules are OR-ed together and identical branches are
+// deduplicated. Fragments whose abort or "log": false rules cannot be combined
+// without changing their meaning are reported as errors. The fragments must
+// already have passed validateAuditLogFilterDefinition.
+func mergeAuditLogFilterDefinitions(fragments []string) (string, error) {
+	var active []mergeFragment
+
+	for i, fragment := range fragments {
+		parsed, err := parseMergeFragment(i, fragment)
+		if err != nil {
+			return "", fmt.Errorf("fragment %d: %w", i, err)
+		}
+
+		if parsed.logsNothing() {
+			continue
+		}
+		active = append(active, parsed)
+	}
+
+	switch len(active) {
+	case 0:
+		return `{"filter":{"log":false}}`, nil
+	case 1:
+		return normalizeJSON(active[0].source)
+	}
+
+	// Class items log the events they match by default, so under a top-level
+	// "log": true they only change the outcome when they exclude or abort
+	// events. Anything else logs every event.
+	for i, fragment := range active {
+		if !fragment.log || len(fragment.classes) == 0 {
+			continue
+		}
+		for _, item := range fragment.classes {
+			if containsKey(item.body, "abort") || restrictsLog(item.body) {
+				return "", fmt.Errorf("fragment %d %s: $.filter.log is true, so the \"log\" and \"abort\" rules of this class only exclude or block events; "+
+					"such exceptions to logging every event cannot be merged with other fragments", fragment.index, item.path)
+			}
+		}
+		active[i].classes = nil
+	}
+
+	for _, fragment := range active {
+		if !fragment.logsEverything() {
+			continue
+		}
+		for _, other := range active {
+			for _, item := range other.classes {
+				if containsKey(item.body, "abort") {
+					return "", fmt.Errorf("fragment %d logs every event, which conflicts with the \"abort\" rule at fragment %d %s",
+						fragment.index, item.fragment, item.path)
+				}
+			}
+		}
+		return `{"filter":{"log":true}}`, nil
+	}
+
+	var order []string
+	groups := map[string][]mergeClassItem{}
+	for _, fragment := range active {
+		for _, item := range fragment.classes {
+			if _, ok := groups[item.name]; !ok {
+				order = append(order, item.name)
+			}
+			groups[item.name] = append(groups[item.name], item)
+		}
+	}
+
+	classes := make([]any, 0, len(order))
+	for _, name := range order {
+		merged, err := mergeClassGroup(name, dedupeClassItems(groups[name]))
+		if err != nil {
+			return "", err
+		}
+		classes = append(classes, merged)
+	}
+
+	result, err := json.Marshal(map[string]any{
+		"filter": map[string]any{
+			"class": classes,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// The fragments were validated by the caller, so an invalid result can only
+	// come from a bug in the merge itself; check it rather than return it.
+	if err := validateAuditLogFilterDefinition(string(result)); err != nil {
+		return "", fmt.Errorf("merged definition is invalid: %w", err)
+	}
+
+	return string(result), nil
+}
+
+func parseMergeFragment(index int, definition string) (mergeFragment, error) {
+	var root map[string]any
+	if err := json.Unmarshal([]byte(definition), &root); err != nil {
+		return mergeFragment{}, err
+	}
+
+	filter, _ := root["filter"].(map[string]any)
+	for key := range filter {
+		if key != "log" && key != "class" {
+			return mergeFragment{}, fmt.Errorf("$.filter.%s is not supported when merging definitions", key)
+		}
+	}
+
+	classes, err := expandMergeClassItems(index, filter["class"], "$.filter.class")
+	if err != nil {
+		return mergeFragment{}, err
+	}
+
+	log := len(classes) == 0
+	if value, ok := filter["log"]; ok {
+		b, ok := value.(bool)
+		if !ok {
+			return mergeFragment{}, fmt.Errorf("$.filter.log must be a boolean when merging definitions")
+		}
+		log = b
+	}
+
+	return mergeFragment{
+		index:   index,
+		source:  definition,
+		log:     log,
+		classes: classes,
+	}, nil
+}
+
+func expandMergeClassItems(fragment int, value any, path string) ([]mergeClassItem, error) {
+	var objects []map[string]any
+	var paths []string
+
+	switch typed := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		objects = append(objects, typed)
+		paths = append(paths, path)
+	case []any:
+		for i, entry := range typed {
+			object, ok := entry.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] must be a class object", path, i)
+			}
+			objects = append(objects, object)
+			paths = append(paths, fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a class object or an array of class objects", path)
+	}
+
+	var items []mergeClassItem
+	for i, object := range objects {
+		names, err := stringOrStringList(object["name"], paths[i]+".name")
+		if err != nil {
+			return nil, err
+		}
+
+		body := make(map[string]any, len(object))
+		for key, child := range object {
+			if key != "name" {
+				body[key] = child
+			}
+		}
+
+		for _, name := range names {
+			items = append(items, mergeClassItem{
+				fragment: fragment,
+				path:     paths[i],
+				name:     name,
+				body:     body,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+func dedupeClassItems(items []mergeClassItem) []mergeClassItem {
+	seen := map[string]bool{}
+	var unique []mergeClassItem
+	for _, item := range items {
+		key := canonicalJSON(item.body)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, item)
+	}
+	return unique
+}
+
+func mergeClassGroup(name string, items []mergeClassItem) (map[string]any, error) {
+	if len(items) == 1 {
+		return withName(name, items[0].body), nil
+	}
+
+	var classWide []mergeEventRule
+	var eventOrder []string
+	events := map[string]*mergeEventRule{}
+
+	for _, item := range items {
+		if containsKey(item.body, "abort") {
+			return nil, fmt.Errorf("fragment %d %s: \"abort\" rule for class %q conflicts with other fragments' rules for the same class",
+				item.fragment, item.path, name)
+		}
+
+		for key := range item.body {
+			if key != "log" && key != "event" {
+				return nil, fmt.Errorf("fragment %d %s.%s is not supported when merging rules for class %q",
+					item.fragment, item.path, key, name)
+			}
+		}
+
+		classLog, hasClassLog := item.body["log"]
+		if isFalse(classLog) {
+			return nil, fmt.Errorf("fragment %d %s: \"log\": false for class %q conflicts with other fragments that log this class",
+				item.fragment, item.path, name)
+		}
+
+		eventValue, hasEvent := item.body["event"]
+		if !hasEvent {
+			rule := mergeEventRule{unconditional: !isCondition(classLog)}
+			if isCondition(classLog) {
+				rule.conditions = []any{classLog}
+			}
+			classWide = append(classWide, rule)
+			continue
+		}
+
+		if hasClassLog {
+			return nil, fmt.Errorf("fragment %d %s: class-level \"log\" combined with event rules for class %q cannot be merged",
+				item.fragment, item.path, name)
+		}
+
+		eventItems, err := expandMergeEventItems(item, eventValue, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, eventItem := range eventItems {
+			rule, ok := events[eventItem.name]
+			if !ok {
+				rule = &mergeEventRule{name: eventItem.name}
+				events[eventItem.name] = rule
+				eventOrder = append(eventOrder, eventItem.name)
+			}
+			rule.unconditional = rule.unconditional || eventItem.unconditional
+			rule.conditions = append(rule.conditions, eventItem.conditions...)
+		}
+	}
+
+	if len(classWide) > 0 {
+		var conditions []any
+		for _, rule := range classWide {
+			if rule.unconditional {
+				return map[string]any{"name": name}, nil
+			}
+			conditions = append(conditions, rule.conditions...)
+		}
+		if len(eventOrder) > 0 {
+			return nil, fmt.Errorf("class %q combines a class-wide \"log\" condition with event-specific rules; these cannot be merged", name)
+		}
+		return map[string]any{"name": name, "log": orConditions(conditions)}, nil
+	}
+
+	// Group events that end up with the same log condition into one event item.
+	var groupOrder []string
+	grouped := map[string][]string{}
+	conditions := map[string]any{}
+	for _, eventName := range eventOrder {
+		rule := events[eventName]
+		var condition any
+		if !rule.unconditional {
+			condition = orConditions(rule.conditions)
+		}
+		key := canonicalJSON(condition)
+		if _, ok := grouped[key]; !ok {
+			groupOrder = append(groupOrder, key)
+			conditions[key] = condition
+		}
+		grouped[key] = append(grouped[key], eventName)
+	}
+
+	eventList := make([]any, 0, len(groupOrder))
+	for _, key := range groupOrder {
+		names := grouped[key]
+		event := map[string]any{}
+		if len(names) == 1 {
+			event["name"] = names[0]
+		} else {
+			event["name"] = stringsToAny(names)
+		}
+		if conditions[key] != nil {
+			event["log"] = conditions[key]
+		}
+		eventList = append(eventList, event)
+	}
+
+	return map[string]any{"name": name, "event": eventList}, nil
+}
+
+func expandMergeEventItems(item mergeClassItem, value any, className string) ([]mergeEventRule, error) {
+	var objects []map[string]any
+	var paths []string
+	basePath := item.path + ".event"
+
+	switch typed := value.(type) {
+	case map[string]any:
+		objects = append(objects, typed)
+		paths = append(paths, basePath)
+	case []any:
+		for i, entry := range typed {
+			object, ok := entry.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("fragment %d %s[%d] must be an event object", item.fragment, basePath, i)
+			}
+			objects = append(objects, object)
+			paths = append(paths, fmt.Sprintf("%s[%d]", basePath, i))
+		}
+	default:
+		return nil, fmt.Errorf("fragment %d %s must be an event object or an array of event objects", item.fragment, basePath)
+	}
+
+	var rules []mergeEventRule
+	for i, object := range objects {
+		for key := range object {
+			if key != "name" && key != "log" {
+				return nil, fmt.Errorf("fragment %d %s.%s is not supported when merging rules for class %q",
+					item.fragment, paths[i], key, className)
+			}
+		}
+
+		names, err := stringOrStringList(object["name"], paths[i]+".name")
+		if err != nil {
+			return nil, fmt.Errorf("fragment %d %w", item.fragment, err)
+		}
+
+		logValue := object["log"]
+		if isFalse(logValue) {
+			return nil, fmt.Errorf("fragment %d %s: \"log\": false for class %q conflicts with other fragments that log this class",
+				item.fragment, paths[i], className)
+		}
+
+		for _, name := range names {
+			rule := mergeEventRule{name: name, unconditional: !isCondition(logValue)}
+			if isCondition(logValue) {
+				rule.conditions = []any{logValue}
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// orConditions combines log conditions with "or", flattening nested "or"
+// operators and dropping duplicate branches.
+func orConditions(conditions []any) any {
+	seen := map[string]bool{}
+	var branches []any
+
+	var add func(condition any)
+	add = func(condition any) {
+		if object, ok := condition.(map[string]any); ok && len(object) == 1 {
+			if nested, ok := object["or"].([]any); ok {
+				for _, branch := range nested {
+					add(branch)
+				}
+				return
+			}
+		}
+		key := canonicalJSON(condition)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		branches = append(branches, condition)
+	}
+
+	for _, condition := range conditions {
+		add(condition)
+	}
+
+	if len(branches) == 1 {
+		return branches[0]
+	}
+	return map[string]any{"or": branches}
+}
+
+func stringOrStringList(value any, path string) ([]string, error) {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}, nil
+	case []any:
+		names := make([]string, 0, len(typed))
+		for i, entry := range typed {
+			name, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] must be a string", path, i)
+			}
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("%s must not be empty", path)
+		}
+		return names, nil
+	case nil:
+		return nil, fmt.Errorf("%s is required", path)
+	default:
+		return nil, fmt.Errorf("%s must be a string or an array of strings", path)
+	}
+}
+
+func containsKey(value any, key string) bool {
+	switch typed := value.(type) {
+	case map[string]any:
+		for k, child := range typed {
+			if k == key || containsKey(child, key) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range typed {
+			if containsKey(child, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// restrictsLog reports whether a class or event item, or an item nested in
+// it, has a "log" rule other than true: false, or a condition that leaves out
+// the events it does not match.
+func restrictsLog(value any) bool {
+	switch typed := value.(type) {
+	case map[string]any:
+		if log, ok := typed["log"]; ok && log != true {
+			return true
+		}
+		for _, child := range typed {
+			if restrictsLog(child) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range typed {
+			if restrictsLog(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isFalse(value any) bool {
+	b, ok := value.(bool)
+	return ok && !b
+}
+
+func isCondition(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
+
+func withName(name string, body map[string]any) map[string]any {
+	result := make(map[string]any, len(body)+1)
+	for key, value := range body {
+		result[key] = value
+	}
+	result["name"] = name
+	return result
+}
+
+func stringsToAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
+
+// canonicalJSON renders a decoded JSON value with sorted object keys so that
+// structurally identical values compare equal.
+func canonicalJSON(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeAuditLogFilterDefinitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		fragments   []string
+		want        string
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:      "single fragment is normalized",
+			fragments: []string{`{ "filter": { "class": { "name": "connection" } } }`},
+			want:      `{"filter":{"class":{"name":"connection"}}}`,
+		},
+		{
+			name:      "no fragments logs nothing",
+			fragments: nil,
+			want:      `{"filter":{"log":false}}`,
+		},
+		{
+			name: "different classes are combined",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection"}}}`,
+				`{"filter":{"class":{"name":"table_access"}}}`,
+			},
+			want: `{"filter":{"class":[{"name":"connection"},{"name":"table_access"}]}}`,
+		},
+		{
+			name: "identical classes are deduplicated",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection"}}}`,
+				`{"filter":{"class":[{"name":"connection"},{"name":"general"}]}}`,
+			},
+			want: `{"filter":{"class":[{"name":"connection"},{"name":"general"}]}}`,
+		},
+		{
+			name: "event rules for the same class are OR-ed",
+			fragments: []string{
+				`{"filter":{"class":{"name":"table_access","event":{"name":"read","log":{"field":{"name":"table_database.str","value":"pii"}}}}}}`,
+				`{"filter":{"class":{"name":"table_access","event":{"name":"read","log":{"field":{"name":"table_database.str","value":"hr"}}}}}}`,
+			},
+			want: `{"filter":{"class":[{"event":[{"log":{"or":[{"field":{"name":"table_database.str","value":"pii"}},{"field":{"name":"table_database.str","value":"hr"}}]},"name":"read"}],"name":"table_access"}]}}`,
+		},
+		{
+			name: "unconditional event wins over conditional one",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection","event":{"name":["connect","disconnect"]}}}}`,
+				`{"filter":{"class":{"name":"connection","event":{"name":"connect","log":{"field":{"name":"user.str","value":"app"}}}}}}`,
+			},
+			want: `{"filter":{"class":[{"event":[{"name":["connect","disconnect"]}],"name":"connection"}]}}`,
+		},
+		{
+			name: "unconditional class subsumes event rules",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection"}}}`,
+				`{"filter":{"class":{"name":"connection","event":{"name":"connect"}}}}`,
+			},
+			want: `{"filter":{"class":[{"name":"connection"}]}}`,
+		},
+		{
+			name: "log everything fragment subsumes the rest",
+			fragments: []string{
+				`{"filter":{"log":true}}`,
+				`{"filter":{"class":{"name":"connection"}}}`,
+			},
+			want: `{"filter":{"log":true}}`,
+		},
+		{
+			name: "log nothing fragment is ignored",
+			fragments: []string{
+				`{"filter":{"log":false}}`,
+				`{"filter":{"class":{"name":"connection"}}}`,
+			},
+			want: `{"filter":{"class":{"name":"connection"}}}`,
+		},
+		{
+			name: "conflicting abort rules",
+			fragments: []string{
+				`{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`,
+				`{"filter":{"class":{"name":"table_access","event":{"name":"read"}}}}`,
+			},
+			wantErr:     true,
+			errContains: "\"abort\" rule for class \"table_access\"",
+		},
+		{
+			name: "abort conflicts with log everything",
+			fragments: []string{
+				`{"filter":{"log":true}}`,
+				`{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`,
+			},
+			wantErr:     true,
+			errContains: "logs every event",
+		},
+		{
+			name: "log false exclusion cannot be merged",
+			fragments: []string{
+				`{"filter":{"log":true,"class":{"name":"connection","log":false}}}`,
+				`{"filter":{"class":{"name":"table_access"}}}`,
+			},
+			wantErr:     true,
+			errContains: "cannot be merged with other fragments",
+		},
+		{
+			name: "log true with an event exclusion cannot be merged",
+			fragments: []string{
+				`{"filter":{"log":true,"class":{"name":"connection","event":{"name":"connect","log":false}}}}`,
+				`{"filter":{"class":{"name":"table_access"}}}`,
+			},
+			wantErr:     true,
+			errContains: "fragment 0 $.filter.class",
+		},
+		{
+			name: "log true with an abort cannot be merged",
+			fragments: []string{
+				`{"filter":{"log":true,"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`,
+				`{"filter":{"class":{"name":"connection"}}}`,
+			},
+			wantErr:     true,
+			errContains: "only exclude or block events",
+		},
+		{
+			name: "log true with logging class rules logs everything",
+			fragments: []string{
+				`{"filter":{"log":true,"class":[{"name":"connection"},{"name":"general","log":true}]}}`,
+				`{"filter":{"class":{"name":"table_access"}}}`,
+			},
+			want: `{"filter":{"log":true}}`,
+		},
+		{
+			name: "class log false conflicts with other fragment",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection","log":false}}}`,
+				`{"filter":{"class":{"name":"connection"}}}`,
+			},
+			wantErr:     true,
+			errContains: "\"log\": false for class \"connection\"",
+		},
+		{
+			name: "unsupported fragment is reported with its index",
+			fragments: []string{
+				`{"filter":{"class":{"name":"connection"}}}`,
+				`{"filter":{"event":{"name":"connect"}}}`,
+			},
+			wantErr:     true,
+			errContains: "fragment 1",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := mergeAuditLogFilterDefinitions(tc.fragments)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error but got none (result %s)", got)
+				}
+				if tc.errContains != "" && !strings.Contains(err.Error(), tc.errContains) {
+					t.Fatalf("expected error to contain %q, got: %q", tc.errContains, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected merged definition:\n got: %s\nwant: %s", got, tc.want)
+			}
+			if err := validateAuditLogFilterDefinition(got); err != nil {
+				t.Fatalf("merged definition failed validation: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrConditionsFlattensAndDeduplicates(t *testing.T) {
+	t.Parallel()
+
+	a := map[string]any{"field": map[string]any{"name": "user.str", "value": "a"}}
+	b := map[string]any{"field": map[string]any{"name": "user.str", "value": "b"}}
+
+	got := canonicalJSON(orConditions([]any{a, map[string]any{"or": []any{a, b}}, b}))
+	want := `{"or":[{"field":{"name":"user.str","value":"a"}},{"field":{"name":"user.str","value":"b"}}]}`
+	if got != want {
+		t.Fatalf("unexpected condition:\n got: %s\nwant: %s", got, want)
+	}
+
+	if got := canonicalJSON(orConditions([]any{a, a})); got != canonicalJSON(a) {
+		t.Fatalf("expected a single branch to be returned unwrapped, got: %s", got)
+	}
+}
@@ -32,9 +32,41 @@ func (v auditLogFilterDefinitionValidator) ValidateString(ctx context.Context, r
 			"Invalid JSON Definition",
 			err.Error(),
 		)
+		return
+	}
+
+	findings, err := lintAuditLogFilterDefinition(req.ConfigValue.ValueString())
+	if err != nil {
+		return
+	}
+	for _, finding := range findings {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Suspicious Filter Definition",
+			finding.Path+": "+finding.Message,
+		)
 	}
 }
 
+// definitionPathError is a validation error located at a JSON path within the
+// definition. Its message is unchanged so existing diagnostics stay the same.
+type definitionPathError struct {
+	path string
+	err  error
+}
+
+func (e *definitionPathError) Error() string {
+	return e.err.Error()
+}
+
+func (e *definitionPathError) Unwrap() error {
+	return e.err
+}
+
+func definitionErrorf(path, format string, args ...any) error {
+	return &definitionPathError{path: path, err: fmt.Errorf(format, args...)}
+}
+
 func validateAuditLogFilterDefinition(definition string) error {
 	var parsed any
 
@@ -44,16 +76,16 @@ func validateAuditLogFilterDefinition(definition string) error {
 
 	rootObject, ok := parsed.(map[string]any)
 	if !ok {
-		return fmt.Errorf("the filter definition must be a JSON object")
+		return definitionErrorf("$", "the filter definition must be a JSON object")
 	}
 
-	filterValue, exists := rootObject["filter"]
+	filterValue, exists := rootObject[definitionRootKey]
 	if !exists {
-		return fmt.Errorf("the filter definition must include a top-level \"filter\" object")
+		return definitionErrorf("$", "the filter definition must include a top-level \"filter\" object")
 	}
 
 	if _, ok := filterValue.(map[string]any); !ok {
-		return fmt.Errorf("the \"filter\" value must be a JSON object")
+		return definitionErrorf("$.filter", "the \"filter\" value must be a JSON object")
 	}
 
 	if err := validateConditionTree(parsed, "$"); err != nil {
@@ -78,52 +110,65 @@ func validateConditionTree(value any, path string) error {
 	return nil
 }
 
-func validateConditionObject(object map[string]any, path string) error {
-	operatorKeys := []string{"and", "or", "not", "field"}
+// conditionOperator returns the logical operator rule of a condition object,
+// or nil when the object is not a condition. Objects that mix several
+// operators are rejected.
+func conditionOperator(object map[string]any, path string) (*conditionOperatorRule, error) {
 	var present []string
+	var rule *conditionOperatorRule
 
-	for _, key := range operatorKeys {
-		if _, ok := object[key]; ok {
-			present = append(present, key)
+	for i := range conditionOperatorRules {
+		if _, ok := object[conditionOperatorRules[i].name]; ok {
+			present = append(present, conditionOperatorRules[i].name)
+			rule = &conditionOperatorRules[i]
 		}
 	}
 
-	if len(present) > 0 {
-		if len(present) != 1 {
-			return fmt.Errorf("%s contains multiple logical operators (%s); each condition object must contain exactly one of and, or, not, field",
-				path, strings.Join(present, ", "))
-		}
+	if len(present) > 1 {
+		return nil, definitionErrorf(path, "%s contains multiple logical operators (%s); each condition object must contain exactly one of and, or, not, field",
+			path, strings.Join(present, ", "))
+	}
+
+	return rule, nil
+}
+
+func validateConditionObject(object map[string]any, path string) error {
+	rule, err := conditionOperator(object, path)
+	if err != nil {
+		return err
+	}
 
-		operator := present[0]
+	if rule != nil {
+		operator := rule.name
 		operatorValue := object[operator]
 
-		switch operator {
-		case "and", "or":
+		switch rule.operand {
+		case operandConditionList:
 			expressions, ok := operatorValue.([]any)
 			if !ok {
-				return fmt.Errorf("%s.%s must be an array of condition objects", path, operator)
+				return definitionErrorf(path+"."+operator, "%s.%s must be an array of condition objects", path, operator)
 			}
 			if len(expressions) == 0 {
-				return fmt.Errorf("%s.%s must contain at least one condition object", path, operator)
+				return definitionErrorf(path+"."+operator, "%s.%s must contain at least one condition object", path, operator)
 			}
 			for i, expression := range expressions {
 				if _, ok := expression.(map[string]any); !ok {
-					return fmt.Errorf("%s.%s[%d] must be a condition object", path, operator, i)
+					return definitionErrorf(fmt.Sprintf("%s.%s[%d]", path, operator, i), "%s.%s[%d] must be a condition object", path, operator, i)
 				}
 				if err := validateConditionTree(expression, fmt.Sprintf("%s.%s[%d]", path, operator, i)); err != nil {
 					return err
 				}
 			}
 			return nil
-		case "not":
+		case operandCondition:
 			nested, ok := operatorValue.(map[string]any)
 			if !ok {
-				return fmt.Errorf("%s.not must be a condition object", path)
+				return definitionErrorf(path+"."+operator, "%s.%s must be a condition object", path, operator)
 			}
-			return validateConditionTree(nested, fmt.Sprintf("%s.not", path))
-		case "field":
+			return validateConditionTree(nested, fmt.Sprintf("%s.%s", path, operator))
+		case operandObject:
 			if _, ok := operatorValue.(map[string]any); !ok {
-				return fmt.Errorf("%s.field must be an object", path)
+				return definitionErrorf(path+"."+operator, "%s.%s must be an object", path, operator)
 			}
 			return nil
 		}
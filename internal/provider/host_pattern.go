@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// maxHostPatternLength is the width of the host column of MySQL account tables.
+const maxHostPatternLength = 255
+
+// normalizeHostPattern returns a host pattern as the server stores it. MySQL
+// lowercases the host part of account names, so LOCALHOST and localhost are
+// the same account.
+func normalizeHostPattern(host string) string {
+	return strings.ToLower(host)
+}
+
+// validateHostPattern checks a host pattern against the MySQL account-name
+// host syntax: host names and IP addresses with % and _ wildcards, IPv6
+// addresses, and IPv4 address/netmask or address/prefix-length forms.
+func validateHostPattern(host string) error {
+	if host == "" {
+		return errors.New("host must not be empty; use '%' to match any host")
+	}
+	if len(host) > maxHostPatternLength {
+		return fmt.Errorf("host must be at most %d characters", maxHostPatternLength)
+	}
+
+	if address, mask, ok := strings.Cut(host, "/"); ok {
+		return validateHostNetmask(address, mask)
+	}
+
+	if strings.Contains(host, ":") {
+		return validateIPv6Pattern(host)
+	}
+
+	return validateHostnamePattern(host)
+}
+
+func hasHostWildcard(host string) bool {
+	return strings.ContainsAny(host, "%_")
+}
+
+// validateHostNetmask checks the address/netmask and CIDR forms, which MySQL
+// only supports for literal IPv4 addresses.
+func validateHostNetmask(address, mask string) error {
+	if hasHostWildcard(address) || hasHostWildcard(mask) {
+		return fmt.Errorf("%q: wildcards cannot be combined with a netmask; use either %q or a literal network address with a netmask",
+			address+"/"+mask, address)
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() == nil || strings.Contains(address, ":") {
+		return fmt.Errorf("%q: netmask and CIDR forms are only supported for IPv4 addresses", address+"/"+mask)
+	}
+
+	var ipMask net.IPMask
+	if prefix, err := strconv.Atoi(mask); err == nil && !strings.Contains(mask, ".") {
+		if prefix < 0 || prefix > 32 {
+			return fmt.Errorf("%q: CIDR prefix length must be between 0 and 32", address+"/"+mask)
+		}
+		ipMask = net.CIDRMask(prefix, 32)
+	} else {
+		maskIP := net.ParseIP(mask)
+		if maskIP == nil || maskIP.To4() == nil {
+			return fmt.Errorf("%q: netmask must be an IPv4 address such as 255.255.255.0 or a prefix length such as 24", address+"/"+mask)
+		}
+		ipMask = net.IPMask(maskIP.To4())
+		if _, bits := ipMask.Size(); bits == 0 {
+			return fmt.Errorf("%q: netmask %s is not contiguous", address+"/"+mask, mask)
+		}
+	}
+
+	network := ip.To4().Mask(ipMask)
+	if !network.Equal(ip.To4()) {
+		return fmt.Errorf("%q: address has host bits set outside the netmask and would never match; use %s/%s",
+			address+"/"+mask, network, mask)
+	}
+
+	return nil
+}
+
+func validateIPv6Pattern(host string) error {
+	for _, c := range host {
+		if !strings.ContainsRune("0123456789abcdefABCDEF:.%_", c) {
+			return fmt.Errorf("%q: invalid character %q in IPv6 address", host, c)
+		}
+	}
+
+	if !hasHostWildcard(host) && net.ParseIP(host) == nil {
+		return fmt.Errorf("%q is not a valid IPv6 address", host)
+	}
+
+	return nil
+}
+
+func validateHostnamePattern(host string) error {
+	numeric := true
+	for _, c := range host {
+		switch {
+		case c >= '0' && c <= '9', c == '.', c == '%', c == '_':
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+			numeric = false
+		default:
+			return fmt.Errorf("%q: invalid character %q in host name", host, c)
+		}
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("%q: host name has an empty label", host)
+		}
+	}
+
+	if numeric && host != "%" && host != "_" {
+		return validateIPv4Pattern(host, labels)
+	}
+
+	return nil
+}
+
+// validateIPv4Pattern checks host patterns made of digits, dots and
+// wildcards, which MySQL compares as IPv4 addresses.
+func validateIPv4Pattern(host string, octets []string) error {
+	if len(octets) > 4 {
+		return fmt.Errorf("%q: IPv4 address has more than four octets", host)
+	}
+
+	for _, octet := range octets {
+		if hasHostWildcard(octet) {
+			continue
+		}
+		value, err := strconv.Atoi(octet)
+		if err != nil || value > 255 {
+			return fmt.Errorf("%q: IPv4 octet %q must be between 0 and 255", host, octet)
+		}
+	}
+
+	if len(octets) < 4 && !hasHostWildcard(octets[len(octets)-1]) {
+		return fmt.Errorf("%q is an incomplete IPv4 address; use %q to match the subnet", host, host+".%")
+	}
+
+	return nil
+}
+
+// hostPatternValidator validates the userhost attribute.
+type hostPatternValidator struct{}
+
+var _ validator.String = hostPatternValidator{}
+
+func (v hostPatternValidator) Description(context.Context) string {
+	return "must be a MySQL host pattern: a host name or IP address with optional % and _ wildcards, or an IPv4 address with a netmask or CIDR prefix"
+}
+
+func (v hostPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostPatternValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateHostPattern(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Host Pattern",
+			err.Error(),
+		)
+	}
+}
+
+// HostPatternType is a string type whose values compare equal when they name
+// the same MySQL host, so "LOCALHOST" in configuration does not diff against
+// the "localhost" stored by the server.
+type HostPatternType struct {
+	basetypes.StringType
+}
+
+var _ basetypes.StringTypable = HostPatternType{}
+
+func (t HostPatternType) Equal(o attr.Type) bool {
+	other, ok := o.(HostPatternType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t HostPatternType) String() string {
+	return "HostPatternType"
+}
+
+func (t HostPatternType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return HostPatternValue{StringValue: in}, nil
+}
+
+func (t HostPatternType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return HostPatternValue{StringValue: stringValue}, nil
+}
+
+func (t HostPatternType) ValueType(ctx context.Context) attr.Value {
+	return HostPatternValue{}
+}
+
+// HostPatternValue is a value of HostPatternType.
+type HostPatternValue struct {
+	basetypes.StringValue
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = HostPatternValue{}
+
+// NewHostPatternValue returns a known HostPatternValue.
+func NewHostPatternValue(host string) HostPatternValue {
+	return HostPatternValue{StringValue: basetypes.NewStringValue(host)}
+}
+
+func (v HostPatternValue) Equal(o attr.Value) bool {
+	other, ok := o.(HostPatternValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v HostPatternValue) Type(ctx context.Context) attr.Type {
+	return HostPatternType{}
+}
+
+func (v HostPatternValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(HostPatternValue)
+	if !ok {
+		return false, nil
+	}
+	return normalizeHostPattern(v.ValueString()) == normalizeHostPattern(newValue.ValueString()), nil
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateHostPattern(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		host    string
+		wantErr bool
+	}{
+		{host: "%"},
+		{host: "localhost"},
+		{host: "LOCALHOST"},
+		{host: "%.example.com"},
+		{host: "db-01.prod.example.com"},
+		{host: "app_%"},
+		{host: "10.0.0.1"},
+		{host: "10.0.0.%"},
+		{host: "10.%"},
+		{host: "10.0.0.0/255.255.255.0"},
+		{host: "10.0.0.0/24"},
+		{host: "0.0.0.0/0"},
+		{host: "::1"},
+		{host: "2001:db8::1"},
+		{host: "2001:DB8::%"},
+		{host: "", wantErr: true},
+		{host: "10.0.0.%/255.255.255.0", wantErr: true},
+		{host: "10.0.0.1/255.255.255.0", wantErr: true},
+		{host: "10.0.0.0/255.0.255.0", wantErr: true},
+		{host: "10.0.0.0/33", wantErr: true},
+		{host: "example.com/24", wantErr: true},
+		{host: "2001:db8::/64", wantErr: true},
+		{host: "2001:db8::zz", wantErr: true},
+		{host: "2001:db8:::1", wantErr: true},
+		{host: "10.0.0", wantErr: true},
+		{host: "10.0.0.256", wantErr: true},
+		{host: "10.0.0.1.5", wantErr: true},
+		{host: "host..example.com", wantErr: true},
+		{host: "bad host", wantErr: true},
+		{host: "host@example.com", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.host, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateHostPattern(tc.host)
+			if tc.wantErr && err == nil {
+				t.Fatalf("validateHostPattern(%q) expected error", tc.host)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("validateHostPattern(%q) unexpected error: %v", tc.host, err)
+			}
+		})
+	}
+}
+
+func TestHostPatternSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cases := []struct {
+		prior string
+		new   string
+		want  bool
+	}{
+		{prior: "LOCALHOST", new: "localhost", want: true},
+		{prior: "%.Example.COM", new: "%.example.com", want: true},
+		{prior: "2001:DB8::1", new: "2001:db8::1", want: true},
+		{prior: "localhost", new: "127.0.0.1", want: false},
+	}
+
+	for _, tc := range cases {
+		equal, diags := NewHostPatternValue(tc.prior).StringSemanticEquals(ctx, NewHostPatternValue(tc.new))
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if equal != tc.want {
+			t.Fatalf("StringSemanticEquals(%q, %q) = %t, want %t", tc.prior, tc.new, equal, tc.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &LintDefinitionFunction{}
+
+var definitionLintFindingType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"path":    types.StringType,
+		"message": types.StringType,
+	},
+}
+
+func NewLintDefinitionFunction() function.Function {
+	return &LintDefinitionFunction{}
+}
+
+// LintDefinitionFunction defines the lint_definition provider function.
+type LintDefinitionFunction struct{}
+
+func (f *LintDefinitionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "lint_definition"
+}
+
+func (f *LintDefinitionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Report logical mistakes in an audit log filter definition",
+		MarkdownDescription: "Validates an audit log filter definition and returns a list of warnings for logical mistakes " +
+			"such as conditions that are always false, duplicated `or` branches, `not` wrapping an always-true " +
+			"condition and `abort` rules that match every event. Each warning has a JSON `path` and a `message`. " +
+			"Structurally invalid definitions cause the function to fail.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "definition",
+				Description: "JSON audit log filter definition.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: definitionLintFindingType,
+		},
+	}
+}
+
+func (f *LintDefinitionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var definition string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &definition))
+	if resp.Error != nil {
+		return
+	}
+
+	findings, err := lintAuditLogFilterDefinition(definition)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if findings == nil {
+		findings = []definitionLintFinding{}
+	}
+
+	result, diags := types.ListValueFrom(ctx, definitionLintFindingType, findings)
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mysqlClient is the provider data passed to resources, data sources and list
+// resources. It connects on first use, so that operations that never reach
+// the server do not need one, and retries statements that fail with
+// transient errors according to its retry policy. A read-only client is used
+// by plan-only pipelines and refuses every change.
+type mysqlClient struct {
+	config providerValidatedConfig
+
+	// connectMu guards the connection and the cached outcome of opening and
+	// verifying it; a failed verification is not repeated.
+	connectMu  sync.Mutex
+	connected  bool
+	db         *sql.DB
+	connectErr *connectionError
+
+	// lockDB is the pool of connections holding named locks.
+	lockDB *sql.DB
+
+	// plannedFilters holds the names of filters that filter resources plan
+	// to create in this run, so that assignments planned after them can
+	// refer to them before they exist. plannedDefinitions holds the
+	// definitions filter resources plan for their filters, so that
+	// assignments are checked against the definition the filter will have.
+	mu                 sync.Mutex
+	plannedFilters     map[string]bool
+	plannedDefinitions map[string]string
+}
+
+func newMySQLClient(config providerValidatedConfig) *mysqlClient {
+	return &mysqlClient{config: config}
+}
+
+// connectionError carries the diagnostics of a failed connection attempt to
+// the operation that needed the connection; addMySQLError reports them as
+// they are.
+type connectionError struct {
+	diags diag.Diagnostics
+}
+
+func (e *connectionError) Error() string {
+	var messages []string
+	for _, d := range e.diags.Errors() {
+		messages = append(messages, d.Summary()+": "+d.Detail())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// connect opens and verifies the connection the first time it is needed and
+// returns the pool, or a *connectionError.
+func (c *mysqlClient) connect(ctx context.Context) (*sql.DB, error) {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	if !c.connected {
+		c.connected = true
+
+		var diags diag.Diagnostics
+		db, ok := connectAndVerify(ctx, c.config, &diags)
+		for _, warning := range diags.Warnings() {
+			tflog.Warn(ctx, warning.Summary(), map[string]any{"detail": warning.Detail()})
+		}
+		if ok {
+			c.db = db
+		} else {
+			c.connectErr = &connectionError{diags: diags.Errors()}
+		}
+	}
+
+	if c.connectErr != nil {
+		return nil, c.connectErr
+	}
+	return c.db, nil
+}
+
+// Close closes the connection if it was opened. The next operation opens a
+// new one.
+func (c *mysqlClient) Close() error {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	if c.lockDB != nil {
+		_ = c.lockDB.Close()
+		c.lockDB = nil
+	}
+	if c.db == nil {
+		return nil
+	}
+	err := c.db.Close()
+	c.db = nil
+	c.connected = false
+	return err
+}
+
+// refuseWrite reports an error and returns true when the client is
+// read-only. Resources call it before sending any statement that changes the
+// server.
+func (c *mysqlClient) refuseWrite(diags *diag.Diagnostics, operation string) bool {
+	if !c.config.readOnly {
+		return false
+	}
+	diags.AddError(
+		"Provider Is Read Only",
+		"The provider is configured with read_only = true, so it cannot "+operation+". "+
+			"Read-only mode is meant for pipelines that only run terraform plan; apply with a provider that is not read-only.",
+	)
+	return true
+}
+
+// checkConnected reports an error and returns false when the provider did not
+// connect because its configuration is not known until apply.
+func checkConnected(diags *diag.Diagnostics, db *mysqlClient) bool {
+	if db != nil {
+		return true
+	}
+	diags.AddError(
+		"Provider Not Connected",
+		"The provider configuration is not known until apply, so the provider has not connected to MySQL and cannot read the server yet. "+
+			"Run Terraform with deferred actions enabled, or create the server in an earlier apply.",
+	)
+	return false
+}
+
+// planFilter records that a filter resource plans to create name.
+func (c *mysqlClient) planFilter(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plannedFilters == nil {
+		c.plannedFilters = map[string]bool{}
+	}
+	c.plannedFilters[name] = true
+}
+
+// filterPlanned reports whether a filter resource plans to create name.
+func (c *mysqlClient) filterPlanned(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.plannedFilters[name]
+}
+
+// auditWriteFunction matches calls of the audit log filter functions that
+// change filters or assignments, which are run with SELECT.
+var auditWriteFunction = regexp.MustCompile(`(?i)\baudit_log_filter_(set_filter|remove_filter|set_user|remove_user|flush)\s*\(`)
+
+// isWriteQuery reports whether a query run with QueryContext or
+// QueryRowContext changes the server. Statements run with ExecContext are
+// always writes.
+func isWriteQuery(query string) bool {
+	return auditWriteFunction.MatchString(query)
+}
+
+// planFilterDefinition records the definition a filter resource plans for
+// name.
+func (c *mysqlClient) planFilterDefinition(name, definition string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plannedDefinitions == nil {
+		c.plannedDefinitions = map[string]string{}
+	}
+	c.plannedDefinitions[name] = definition
+}
+
+// plannedFilterDefinition returns the definition a filter resource plans for
+// name, if any.
+func (c *mysqlClient) plannedFilterDefinition(name string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	definition, ok := c.plannedDefinitions[name]
+	return definition, ok
+}
+
+// ExecContext runs a statement, retrying transient errors. Statements are
+// treated as writes.
+func (c *mysqlClient) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	db, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result sql.Result
+	err = c.config.retry.do(ctx, query, true, func() error {
+		result, err = db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// QueryContext runs a query, retrying transient errors until it returns rows.
+// Errors while reading the rows are not retried.
+func (c *mysqlClient) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	db, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows *sql.Rows
+	err = c.config.retry.do(ctx, query, isWriteQuery(query), func() error {
+		rows, err = db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRowContext returns a row that runs the query when it is scanned, so
+// that errors surfacing in Scan are retried as well.
+func (c *mysqlClient) QueryRowContext(ctx context.Context, query string, args ...any) *retryRow {
+	return &retryRow{client: c, ctx: ctx, query: query, args: args}
+}
+
+// retryRow is the result of mysqlClient.QueryRowContext.
+type retryRow struct {
+	client *mysqlClient
+	ctx    context.Context
+	query  string
+	args   []any
+}
+
+// Scan runs the query and copies the first row into dest, like
+// (*sql.Row).Scan. sql.ErrNoRows is returned without retrying.
+func (r *retryRow) Scan(dest ...any) error {
+	db, err := r.client.connect(r.ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.client.config.retry.do(r.ctx, r.query, isWriteQuery(r.query), func() error {
+		return db.QueryRowContext(r.ctx, r.query, r.args...).Scan(dest...)
+	})
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestMySQLClientRefuseWrite(t *testing.T) {
+	t.Parallel()
+
+	var diagnostics diag.Diagnostics
+	if newMySQLClient(providerValidatedConfig{}).refuseWrite(&diagnostics, "create audit log filters") {
+		t.Fatalf("expected a writable client to allow writes")
+	}
+	if diagnostics.HasError() {
+		t.Fatalf("expected no diagnostics, got: %+v", diagnostics)
+	}
+
+	if !newMySQLClient(providerValidatedConfig{readOnly: true}).refuseWrite(&diagnostics, "create audit log filters") {
+		t.Fatalf("expected a read-only client to refuse writes")
+	}
+	if len(diagnostics) != 1 || diagnostics[0].Summary() != "Provider Is Read Only" {
+		t.Fatalf("unexpected diagnostics: %+v", diagnostics)
+	}
+	if !strings.Contains(diagnostics[0].Detail(), "cannot create audit log filters") {
+		t.Fatalf("expected detail to name the refused operation, got: %s", diagnostics[0].Detail())
+	}
+}
+
+func TestCheckPlannedFilterExistsPlannedFilter(t *testing.T) {
+	t.Parallel()
+
+	// The client has no connection; a planned filter must not need one.
+	client := newMySQLClient(providerValidatedConfig{})
+	if client.filterPlanned("pii") {
+		t.Fatalf("expected no planned filters on a new client")
+	}
+
+	client.planFilter("pii")
+	if !client.filterPlanned("pii") {
+		t.Fatalf("expected filter to be planned")
+	}
+	if client.filterPlanned("other") {
+		t.Fatalf("expected only the recorded filter to be planned")
+	}
+
+	diags := checkPlannedFilterExists(context.Background(), client, path.Root("filter_name"), "pii")
+	if diags.HasError() {
+		t.Fatalf("expected planned filter to pass, got: %+v", diags)
+	}
+}
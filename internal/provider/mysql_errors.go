@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// auditResultError is the string an audit_log_filter_* function returns
+// instead of "OK" when it fails.
+type auditResultError string
+
+func (e auditResultError) Error() string {
+	return "MySQL returned an error: " + string(e)
+}
+
+// errorTranslation is a known cause of a failed statement, with the steps to
+// resolve it. Causes that concern a single attribute are reported on the
+// attribute the failing operation is about.
+type errorTranslation struct {
+	summary     string
+	remediation string
+	attribute   bool
+}
+
+var (
+	missingPrivilegeTranslation = errorTranslation{
+		summary: "Missing Privilege",
+		remediation: "The account the provider connects as lacks a privilege this operation needs. " +
+			"Managing filters and assignments requires AUDIT_ADMIN, reading them requires SELECT on the mysql schema, " +
+			"and server-side expiry requires EVENT on the connection's default database, for example:\n\n" +
+			"  GRANT AUDIT_ADMIN ON *.* TO 'terraform'@'%';\n" +
+			"  GRANT SELECT ON mysql.* TO 'terraform'@'%';",
+	}
+	componentNotLoadedTranslation = errorTranslation{
+		summary: "Audit Log Filter Component Not Loaded",
+		remediation: "The audit_log_filter functions or tables do not exist on the server. Install the component and retry:\n\n" +
+			"  INSTALL COMPONENT 'file://component_audit_log_filter';",
+	}
+	readOnlyTranslation = errorTranslation{
+		summary: "Server Is Read Only",
+		remediation: "The server runs with read_only or super_read_only enabled, as replicas usually do. " +
+			"Point the provider at the source server, or disable read-only mode before applying.",
+	}
+	invalidDefinitionTranslation = errorTranslation{
+		summary: "Invalid Filter Definition",
+		remediation: "The server rejected the filter definition. Check it against the filter definition grammar, " +
+			"for example with `terraform-provider-auditlogfilters validate`, and correct the reported problem.",
+		attribute: true,
+	}
+	lockNotAcquiredTranslation = errorTranslation{
+		summary: "Lock Not Acquired",
+		remediation: "Another Terraform run or session is changing the same filters. Wait for it to finish and retry, or raise named_lock_timeout. " +
+			"The holder's connection ID can be looked up in SHOW PROCESSLIST.",
+	}
+	filterNotFoundTranslation = errorTranslation{
+		summary:     "Filter Not Found",
+		remediation: "The server has no filter with this name. Create it first, or reference the filter resource so that it is created before the assignment.",
+		attribute:   true,
+	}
+)
+
+// translateMySQLError returns the known cause of err, if any. err is a driver
+// error, an auditResultError or a lockTimeoutError.
+func translateMySQLError(err error) (errorTranslation, bool) {
+	var result auditResultError
+	if errors.As(err, &result) {
+		return translateAuditResult(string(result))
+	}
+
+	var lockErr *lockTimeoutError
+	if errors.As(err, &lockErr) {
+		return lockNotAcquiredTranslation, true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return errorTranslation{}, false
+	}
+
+	message := strings.ToLower(mysqlErr.Message)
+	switch mysqlErr.Number {
+	case 1044, 1142, 1227, 1370:
+		return missingPrivilegeTranslation, true
+	case 1305:
+		return componentNotLoadedTranslation, true
+	case 1146:
+		if strings.Contains(message, "audit_log") {
+			return componentNotLoadedTranslation, true
+		}
+	case 1290:
+		if strings.Contains(message, "read-only") || strings.Contains(message, "read_only") {
+			return readOnlyTranslation, true
+		}
+	case 1792, 1836:
+		return readOnlyTranslation, true
+	case 3058:
+		return lockNotAcquiredTranslation, true
+	case 3140, 3141:
+		return invalidDefinitionTranslation, true
+	}
+
+	return errorTranslation{}, false
+}
+
+// translateAuditResult classifies the error string of an audit_log_filter_*
+// function. The wording differs between server versions, so it is matched by
+// keyword.
+func translateAuditResult(result string) (errorTranslation, bool) {
+	message := strings.ToLower(result)
+
+	for _, keyword := range []string{"json", "pars", "definition", "rule", "format"} {
+		if strings.Contains(message, keyword) {
+			return invalidDefinitionTranslation, true
+		}
+	}
+
+	if strings.Contains(message, "filter") {
+		for _, keyword := range []string{"not exist", "not found", "unknown", "find"} {
+			if strings.Contains(message, keyword) {
+				return filterNotFoundTranslation, true
+			}
+		}
+	}
+
+	return errorTranslation{}, false
+}
+
+// addMySQLError reports a failed statement. Errors with a known cause get a
+// specific summary and remediation appended to detail, and are reported on
+// attribute when the cause concerns it; pass path.Empty() when the operation
+// is not about a single attribute. Connection failures are reported with the
+// diagnostics of the connection attempt. Other errors are reported as summary
+// and detail.
+func addMySQLError(diags *diag.Diagnostics, attribute path.Path, err error, summary, detail string) {
+	// The provider connects on first use; a failed connection is reported
+	// with its own diagnostics rather than as a failed statement.
+	var connErr *connectionError
+	if errors.As(err, &connErr) {
+		diags.Append(connErr.diags...)
+		return
+	}
+
+	translation, ok := translateMySQLError(err)
+	if !ok {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	detail += "\n\n" + translation.remediation
+	if translation.attribute && !attribute.Equal(path.Empty()) {
+		diags.AddAttributeError(attribute, translation.summary, detail)
+		return
+	}
+	diags.AddError(translation.summary, detail)
+}
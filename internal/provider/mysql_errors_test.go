@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestTranslateMySQLError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		err         error
+		wantSummary string
+	}{
+		{
+			name:        "audit_admin_missing",
+			err:         &mysql.MySQLError{Number: 1227, Message: "Access denied; you need (at least one of) the AUDIT_ADMIN privilege(s) for this operation"},
+			wantSummary: "Missing Privilege",
+		},
+		{
+			name:        "table_access_denied_wrapped",
+			err:         fmt.Errorf("read assignments: %w", &mysql.MySQLError{Number: 1142, Message: "SELECT command denied to user 'tf'@'%' for table 'audit_log_user'"}),
+			wantSummary: "Missing Privilege",
+		},
+		{
+			name:        "function_missing",
+			err:         &mysql.MySQLError{Number: 1305, Message: "FUNCTION mysql.audit_log_filter_set_filter does not exist"},
+			wantSummary: "Audit Log Filter Component Not Loaded",
+		},
+		{
+			name:        "filter_table_missing",
+			err:         &mysql.MySQLError{Number: 1146, Message: "Table 'mysql.audit_log_filter' doesn't exist"},
+			wantSummary: "Audit Log Filter Component Not Loaded",
+		},
+		{
+			name: "other_table_missing",
+			err:  &mysql.MySQLError{Number: 1146, Message: "Table 'mysql.role_edges' doesn't exist"},
+		},
+		{
+			name:        "super_read_only",
+			err:         &mysql.MySQLError{Number: 1290, Message: "The MySQL server is running with the --super-read-only option so it cannot execute this statement"},
+			wantSummary: "Server Is Read Only",
+		},
+		{
+			name: "secure_file_priv",
+			err:  &mysql.MySQLError{Number: 1290, Message: "The MySQL server is running with the --secure-file-priv option so it cannot execute this statement"},
+		},
+		{
+			name:        "invalid_json",
+			err:         &mysql.MySQLError{Number: 3141, Message: "Invalid JSON text in argument 2 to function audit_log_filter_set_filter"},
+			wantSummary: "Invalid Filter Definition",
+		},
+		{
+			name:        "result_json_error",
+			err:         auditResultError("ERROR: JSON parsing error."),
+			wantSummary: "Invalid Filter Definition",
+		},
+		{
+			name:        "result_incorrect_rule",
+			err:         auditResultError("ERROR: Incorrect rule definition."),
+			wantSummary: "Invalid Filter Definition",
+		},
+		{
+			name:        "result_unknown_filter",
+			err:         fmt.Errorf("assign 'app'@'%%': %w", auditResultError("ERROR: Unknown filter name.")),
+			wantSummary: "Filter Not Found",
+		},
+		{
+			name: "result_other",
+			err:  auditResultError("ERROR: Something unexpected happened."),
+		},
+		{
+			name:        "lock_timeout",
+			err:         &lockTimeoutError{name: "auditlogfilters:app", timeout: time.Minute},
+			wantSummary: "Lock Not Acquired",
+		},
+		{
+			name: "syntax_error",
+			err:  &mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"},
+		},
+		{
+			name: "plain_error",
+			err:  errors.New("boom"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			translation, ok := translateMySQLError(tc.err)
+			if ok != (tc.wantSummary != "") {
+				t.Fatalf("translateMySQLError(%v) ok = %t, want %t", tc.err, ok, tc.wantSummary != "")
+			}
+			if translation.summary != tc.wantSummary {
+				t.Fatalf("summary = %q, want %q", translation.summary, tc.wantSummary)
+			}
+		})
+	}
+}
+
+func TestAddMySQLError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		attribute     path.Path
+		err           error
+		wantSummary   string
+		wantAttribute bool
+	}{
+		{
+			name:          "definition_error_on_attribute",
+			attribute:     path.Root("definition"),
+			err:           auditResultError("ERROR: JSON parsing error."),
+			wantSummary:   "Invalid Filter Definition",
+			wantAttribute: true,
+		},
+		{
+			name:        "definition_error_without_attribute",
+			attribute:   path.Empty(),
+			err:         auditResultError("ERROR: JSON parsing error."),
+			wantSummary: "Invalid Filter Definition",
+		},
+		{
+			name:        "privilege_error_not_on_attribute",
+			attribute:   path.Root("filter_name"),
+			err:         &mysql.MySQLError{Number: 1227, Message: "Access denied"},
+			wantSummary: "Missing Privilege",
+		},
+		{
+			name:        "unknown_error_keeps_summary",
+			attribute:   path.Root("definition"),
+			err:         errors.New("boom"),
+			wantSummary: "Failed to Create Filter",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			addMySQLError(&diags, tc.attribute, tc.err, "Failed to Create Filter", "Could not create audit log filter: "+tc.err.Error())
+
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %d", len(diags))
+			}
+			d := diags[0]
+			if d.Summary() != tc.wantSummary {
+				t.Fatalf("summary = %q, want %q", d.Summary(), tc.wantSummary)
+			}
+			if !strings.HasPrefix(d.Detail(), "Could not create audit log filter: ") {
+				t.Fatalf("detail lost its context: %q", d.Detail())
+			}
+
+			_, onAttribute := d.(diag.DiagnosticWithPath)
+			if onAttribute != tc.wantAttribute {
+				t.Fatalf("diagnostic with path = %t, want %t", onAttribute, tc.wantAttribute)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// defaultNamedLockTimeout is the default named_lock_timeout in seconds.
+const defaultNamedLockTimeout = 60
+
+// Named locks serialize changes across Terraform runs and within one run.
+// Changes to a filter or to assignments of it take the filter's lock;
+// filter updates, which remove the filter and reassign its users, also take
+// the global lock first. Locks are always taken in that order.
+const (
+	globalLockName   = "auditlogfilters"
+	filterLockPrefix = "auditlogfilters:"
+
+	// maxLockNameLength is the longest name GET_LOCK accepts.
+	maxLockNameLength = 64
+)
+
+// filterLockName returns the named lock of a filter. Names too long for
+// GET_LOCK are replaced by their hash.
+func filterLockName(filterName string) string {
+	name := filterLockPrefix + filterName
+	if len(name) <= maxLockNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(filterName))
+	return filterLockPrefix + hex.EncodeToString(sum[:16])
+}
+
+// filterLockNames returns the sorted, distinct locks of the given filters,
+// skipping empty names.
+func filterLockNames(filterNames ...string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, filterName := range filterNames {
+		if filterName == "" {
+			continue
+		}
+		name := filterLockName(filterName)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lockTimeoutError reports a named lock that could not be acquired in time.
+type lockTimeoutError struct {
+	name    string
+	holder  sql.NullInt64
+	timeout time.Duration
+}
+
+func (e *lockTimeoutError) Error() string {
+	if !e.holder.Valid {
+		return fmt.Sprintf("timed out after %s waiting for lock '%s'", e.timeout, e.name)
+	}
+	return fmt.Sprintf("timed out after %s waiting for lock '%s', held by connection ID %d", e.timeout, e.name, e.holder.Int64)
+}
+
+// namedLocks are locks held by a dedicated connection, since GET_LOCK locks
+// belong to the session that took them.
+type namedLocks struct {
+	conn  *sql.Conn
+	names []string
+}
+
+// lockPool returns the pool that lock connections are taken from. It is
+// separate from the statement pool and unbounded: a lock holder waiting for a
+// statement connection must not be keeping another lock holder from getting
+// one. Its connections have no read timeout, since GET_LOCK waits for up to
+// the lock timeout before answering.
+func (c *mysqlClient) lockPool(ctx context.Context) (*sql.DB, error) {
+	if _, err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	if c.lockDB == nil {
+		config := c.config.mysqlConfig
+		config.ReadTimeout = 0
+		db, err := sqlOpenFunc("mysql", config.FormatDSN())
+		if err != nil {
+			return nil, fmt.Errorf("open lock connection pool: %w", err)
+		}
+		db.SetConnMaxLifetime(c.config.maxLifetime)
+		c.lockDB = db
+	}
+	return c.lockDB, nil
+}
+
+// acquireLocks takes the named locks in order, waiting up to the configured
+// lock timeout for each, and returns a *lockTimeoutError naming the holder
+// when one is not released in time.
+func (c *mysqlClient) acquireLocks(ctx context.Context, names ...string) (*namedLocks, error) {
+	db, err := c.lockPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open lock connection: %w", err)
+	}
+
+	locks := &namedLocks{conn: conn}
+	timeout := c.config.lockTimeout
+	if timeout <= 0 {
+		timeout = defaultNamedLockTimeout * time.Second
+	}
+
+	for _, name := range names {
+		var acquired sql.NullInt64
+		err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int64(timeout/time.Second)).Scan(&acquired)
+		if err == nil && acquired.Int64 == 1 {
+			locks.names = append(locks.names, name)
+			continue
+		}
+
+		if err == nil {
+			lockErr := &lockTimeoutError{name: name, timeout: timeout}
+			_ = conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?)", name).Scan(&lockErr.holder)
+			err = lockErr
+		} else {
+			err = fmt.Errorf("acquire lock '%s': %w", name, err)
+		}
+		locks.release(ctx)
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+// release releases the locks and returns the connection to the pool. A
+// connection whose locks could not be released is discarded instead, which
+// ends its session and with it the locks.
+func (l *namedLocks) release(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+
+	for i := len(l.names) - 1; i >= 0; i-- {
+		if _, err := l.conn.ExecContext(ctx, "DO RELEASE_LOCK(?)", l.names[i]); err != nil {
+			_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+			break
+		}
+	}
+	_ = l.conn.Close()
+}
+
+// lockFilters acquires the locks of filterNames, preceded by the global lock
+// when global is set, and reports a failure on diags. Callers release the
+// locks once their statements have run.
+func lockFilters(ctx context.Context, db *mysqlClient, diags *diag.Diagnostics, global bool, filterNames ...string) (*namedLocks, bool) {
+	names := filterLockNames(filterNames...)
+	if global {
+		names = append([]string{globalLockName}, names...)
+	}
+
+	locks, err := db.acquireLocks(ctx, names...)
+	if err != nil {
+		addMySQLError(diags, path.Empty(), err, "Failed to Acquire Lock",
+			"Could not acquire the named locks that serialize filter changes: "+err.Error())
+		return nil, false
+	}
+	return locks, true
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilterLockName(t *testing.T) {
+	t.Parallel()
+
+	if got := filterLockName("app"); got != "auditlogfilters:app" {
+		t.Fatalf("filterLockName(app) = %q", got)
+	}
+
+	long := strings.Repeat("f", 64)
+	got := filterLockName(long)
+	if len(got) > maxLockNameLength {
+		t.Fatalf("lock name %q is longer than %d characters", got, maxLockNameLength)
+	}
+	if !strings.HasPrefix(got, filterLockPrefix) {
+		t.Fatalf("lock name %q lost its prefix", got)
+	}
+	if got == filterLockName(long+"g") {
+		t.Fatalf("distinct long filter names share lock %q", got)
+	}
+}
+
+func TestFilterLockNames(t *testing.T) {
+	t.Parallel()
+
+	got := filterLockNames("log_all", "", "app", "log_all")
+	want := []string{"auditlogfilters:app", "auditlogfilters:log_all"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("filterLockNames() = %v, want %v", got, want)
+	}
+}
+
+func TestLockTimeoutErrorNamesHolder(t *testing.T) {
+	t.Parallel()
+
+	err := &lockTimeoutError{name: "auditlogfilters:app", timeout: time.Minute}
+	if strings.Contains(err.Error(), "connection ID") {
+		t.Fatalf("error names a holder it does not know: %q", err.Error())
+	}
+
+	err.holder = sql.NullInt64{Int64: 42, Valid: true}
+	if !strings.Contains(err.Error(), "held by connection ID 42") {
+		t.Fatalf("error does not name the holder: %q", err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+)
+
+// auditTables are the tables the provider reads filters and assignments from.
+var auditTables = []string{"mysql.audit_log_filter", "mysql.audit_log_user"}
+
+// privilegeRequirement is a privilege the provider account needs on target,
+// which is either *.* for global and dynamic privileges or schema.table.
+// Requirements with a feature are only needed by the resources and data
+// sources it names.
+type privilegeRequirement struct {
+	privilege string
+	target    string
+	feature   string
+}
+
+func (r privilegeRequirement) String() string {
+	if r.feature != "" {
+		return r.privilege + " ON " + r.target + " (" + r.feature + ")"
+	}
+	return r.privilege + " ON " + r.target
+}
+
+// requiredPrivileges returns the privileges the provider account needs.
+// Read-only mode only reads the audit tables. database is the connection's
+// default database, where server_side_expiry creates its events; without
+// one server_side_expiry cannot be used, so EVENT is not checked.
+func requiredPrivileges(readOnly bool, database string) []privilegeRequirement {
+	var required []privilegeRequirement
+	if !readOnly {
+		required = append(required, privilegeRequirement{privilege: "AUDIT_ADMIN", target: "*.*"})
+	}
+	for _, table := range auditTables {
+		required = append(required, privilegeRequirement{privilege: "SELECT", target: table})
+	}
+
+	required = append(required,
+		privilegeRequirement{
+			privilege: "SELECT",
+			target:    "mysql.user",
+			feature:   "pattern_assignment, effective_filter and coverage",
+		},
+		privilegeRequirement{privilege: "SELECT", target: "mysql.role_edges", feature: "role_assignment"},
+		privilegeRequirement{privilege: "SELECT", target: "mysql.default_roles", feature: "role_assignment"},
+	)
+	if !readOnly && database != "" {
+		required = append(required, privilegeRequirement{
+			privilege: "EVENT",
+			target:    database + ".*",
+			feature:   "server_side_expiry",
+		})
+	}
+	return required
+}
+
+// accountGrants are the privileges of an account, keyed by the level they
+// were granted at: *.*, schema.* or schema.table.
+type accountGrants map[string]map[string]bool
+
+// grantStatement matches the privilege grants printed by SHOW GRANTS; role
+// grants have no ON clause and do not match.
+var grantStatement = regexp.MustCompile(`^GRANT (.+?) ON (?:(?:TABLE|FUNCTION|PROCEDURE) )?(\S+) TO `)
+
+// columnList matches the column list of a column-level grant such as
+// SELECT (`a`, `b`), which does not grant the privilege on the table.
+var columnList = regexp.MustCompile(`\w+ \([^)]*\)`)
+
+// parseGrants collects the privileges from the output of SHOW GRANTS.
+func parseGrants(statements []string) accountGrants {
+	grants := accountGrants{}
+	for _, statement := range statements {
+		match := grantStatement.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+
+		target := strings.ReplaceAll(match[2], "`", "")
+		if grants[target] == nil {
+			grants[target] = map[string]bool{}
+		}
+
+		privileges := columnList.ReplaceAllString(match[1], "")
+		for _, privilege := range strings.Split(privileges, ",") {
+			privilege = strings.ToUpper(strings.TrimSpace(privilege))
+			if privilege != "" {
+				grants[target][privilege] = true
+			}
+		}
+	}
+	return grants
+}
+
+// has reports whether the account holds privilege on target, directly or
+// through a grant at a higher level. ALL PRIVILEGES covers every privilege.
+func (g accountGrants) has(privilege, target string) bool {
+	levels := []string{"*.*"}
+	if schema, _, ok := strings.Cut(target, "."); ok && target != "*.*" {
+		levels = append(levels, schema+".*", target)
+	}
+
+	for _, level := range levels {
+		if g[level][privilege] || g[level]["ALL PRIVILEGES"] || g[level]["ALL"] {
+			return true
+		}
+	}
+	return false
+}
+
+// missingPrivileges returns the requirements the account does not meet.
+func (g accountGrants) missingPrivileges(required []privilegeRequirement) []privilegeRequirement {
+	var missing []privilegeRequirement
+	for _, requirement := range required {
+		if !g.has(requirement.privilege, requirement.target) {
+			missing = append(missing, requirement)
+		}
+	}
+	return missing
+}
+
+// queryCurrentGrants returns SHOW GRANTS for the provider account, expanded
+// with the privileges of its active roles.
+func queryCurrentGrants(ctx context.Context, db *sql.DB) ([]string, error) {
+	var currentRoles string
+	if err := db.QueryRowContext(ctx, "SELECT CURRENT_ROLE()").Scan(&currentRoles); err != nil {
+		return nil, err
+	}
+
+	query := "SHOW GRANTS FOR CURRENT_USER()"
+	if currentRoles != "" && currentRoles != "NONE" {
+		query += " USING " + currentRoles
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statements []string
+	for rows.Next() {
+		var statement string
+		if err := rows.Scan(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	return statements, rows.Err()
+}
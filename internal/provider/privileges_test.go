@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAccountGrantsMissingPrivileges(t *testing.T) {
+	t.Parallel()
+
+	accountTables := []string{"SELECT ON mysql.user", "SELECT ON mysql.role_edges", "SELECT ON mysql.default_roles"}
+
+	tests := []struct {
+		name         string
+		grants       []string
+		readOnly     bool
+		database     string
+		want         []string
+		wantFeatures []string
+	}{
+		{
+			name: "global_grants",
+			grants: []string{
+				"GRANT SELECT, INSERT ON *.* TO `tf`@`%`",
+				"GRANT AUDIT_ADMIN,SYSTEM_VARIABLES_ADMIN ON *.* TO `tf`@`%`",
+			},
+		},
+		{
+			name:   "all_privileges",
+			grants: []string{"GRANT ALL PRIVILEGES ON *.* TO `root`@`localhost` WITH GRANT OPTION"},
+		},
+		{
+			name: "schema_grant",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `tf`@`%`",
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `mysql`.* TO `tf`@`%`",
+			},
+		},
+		{
+			name: "table_grants",
+			grants: []string{
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `mysql`.`audit_log_filter` TO `tf`@`%`",
+				"GRANT SELECT, UPDATE ON `mysql`.`audit_log_user` TO `tf`@`%`",
+			},
+			wantFeatures: accountTables,
+		},
+		{
+			name: "column_grant_is_not_table_grant",
+			grants: []string{
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `mysql`.`audit_log_filter` TO `tf`@`%`",
+				"GRANT SELECT (`username`, `userhost`) ON `mysql`.`audit_log_user` TO `tf`@`%`",
+			},
+			want:         []string{"SELECT ON mysql.audit_log_user"},
+			wantFeatures: accountTables,
+		},
+		{
+			name: "other_schema",
+			grants: []string{
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `app`.* TO `tf`@`%`",
+			},
+			want:         []string{"SELECT ON mysql.audit_log_filter", "SELECT ON mysql.audit_log_user"},
+			wantFeatures: accountTables,
+		},
+		{
+			name: "role_grant_ignored",
+			grants: []string{
+				"GRANT USAGE ON *.* TO `tf`@`%`",
+				"GRANT `auditor`@`%` TO `tf`@`%`",
+			},
+			want:         []string{"AUDIT_ADMIN ON *.*", "SELECT ON mysql.audit_log_filter", "SELECT ON mysql.audit_log_user"},
+			wantFeatures: accountTables,
+		},
+		{
+			name:     "read_only_needs_select",
+			grants:   []string{"GRANT SELECT ON `mysql`.* TO `planner`@`%`"},
+			readOnly: true,
+		},
+		{
+			name:         "read_only_missing_select",
+			grants:       []string{"GRANT USAGE ON *.* TO `planner`@`%`"},
+			readOnly:     true,
+			want:         []string{"SELECT ON mysql.audit_log_filter", "SELECT ON mysql.audit_log_user"},
+			wantFeatures: accountTables,
+		},
+		{
+			name: "feature_privileges",
+			grants: []string{
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `mysql`.* TO `tf`@`%`",
+				"GRANT EVENT ON `ops`.* TO `tf`@`%`",
+			},
+			database: "ops",
+		},
+		{
+			name: "event_missing",
+			grants: []string{
+				"GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`",
+				"GRANT SELECT ON `mysql`.* TO `tf`@`%`",
+				"GRANT EVENT ON `app`.* TO `tf`@`%`",
+			},
+			database:     "ops",
+			wantFeatures: []string{"EVENT ON ops.*"},
+		},
+		{
+			name:     "read_only_skips_event",
+			grants:   []string{"GRANT SELECT ON `mysql`.* TO `planner`@`%`"},
+			readOnly: true,
+			database: "ops",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got, gotFeatures []string
+			for _, requirement := range parseGrants(tc.grants).missingPrivileges(requiredPrivileges(tc.readOnly, tc.database)) {
+				if requirement.feature != "" {
+					gotFeatures = append(gotFeatures, requirement.privilege+" ON "+requirement.target)
+				} else {
+					got = append(got, requirement.String())
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("missing privileges = %v, want %v", got, tc.want)
+			}
+			if !reflect.DeepEqual(gotFeatures, tc.wantFeatures) {
+				t.Fatalf("missing feature privileges = %v, want %v", gotFeatures, tc.wantFeatures)
+			}
+		})
+	}
+}
@@ -11,8 +11,11 @@ import (
 	"time"
 
 	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +24,8 @@ import (
 
 // Ensure AuditLogFilterProvider satisfies various provider interfaces.
 var _ provider.Provider = &AuditLogFilterProvider{}
+var _ provider.ProviderWithFunctions = &AuditLogFilterProvider{}
+var _ provider.ProviderWithListResources = &AuditLogFilterProvider{}
 
 var errNonPositiveInt64 = errors.New("value must be a positive integer (seconds)")
 
@@ -36,6 +41,7 @@ var (
 		err := db.QueryRowContext(ctx, auditLogFilterComponentQuery).Scan(&componentExists)
 		return componentExists, err
 	}
+	queryGrantsFunc = queryCurrentGrants
 )
 
 // AuditLogFilterProvider defines the provider implementation.
@@ -44,7 +50,7 @@ type AuditLogFilterProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
-	db      *sql.DB
+	client  *mysqlClient
 }
 
 // AuditLogFilterProviderModel describes the provider data model.
@@ -62,6 +68,18 @@ type AuditLogFilterProviderModel struct {
 	WaitTimeout           types.Int64  `tfsdk:"wait_timeout"`
 	InnodbLockWaitTimeout types.Int64  `tfsdk:"innodb_lock_wait_timeout"`
 	LockWaitTimeout       types.Int64  `tfsdk:"lock_wait_timeout"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryInitialDelay     types.String `tfsdk:"retry_initial_delay"`
+	RetryMaxDelay         types.String `tfsdk:"retry_max_delay"`
+	ReadOnly              types.Bool   `tfsdk:"read_only"`
+	NamedLockTimeout      types.Int64  `tfsdk:"named_lock_timeout"`
+	ConnMaxLifetime       types.String `tfsdk:"conn_max_lifetime"`
+	MaxOpenConns          types.Int64  `tfsdk:"max_open_conns"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"`
+	ConnectTimeout        types.String `tfsdk:"connect_timeout"`
+	ReadTimeout           types.String `tfsdk:"read_timeout"`
+	WriteTimeout          types.String `tfsdk:"write_timeout"`
+	ConnectionParams      types.Map    `tfsdk:"connection_params"`
 }
 
 type providerRawConfig struct {
@@ -75,16 +93,30 @@ type providerRawConfig struct {
 	tlsKeyFile               string
 	tlsServerName            string
 	tlsSkipVerifyEnv         string
-	connMaxLifetimeEnv       string
+	connMaxLifetime          string
 	maxOpenConnsEnv          string
 	maxIdleConnsEnv          string
 	waitTimeoutEnv           string
 	innodbLockWaitTimeoutEnv string
 	lockWaitTimeoutEnv       string
+	maxRetriesEnv            string
+	retryInitialDelay        string
+	retryMaxDelay            string
+	readOnlyEnv              string
+	namedLockTimeoutEnv      string
+	connectTimeout           string
+	readTimeout              string
+	writeTimeout             string
+	connectionParams         map[string]string
 	tlsSkipVerify            types.Bool
 	waitTimeout              types.Int64
 	innodbLockWaitTimeout    types.Int64
 	lockWaitTimeout          types.Int64
+	maxRetries               types.Int64
+	readOnly                 types.Bool
+	namedLockTimeout         types.Int64
+	maxOpenConns             types.Int64
+	maxIdleConns             types.Int64
 }
 
 type providerValidatedConfig struct {
@@ -92,6 +124,9 @@ type providerValidatedConfig struct {
 	maxLifetime  time.Duration
 	maxOpenConns int
 	maxIdleConns int
+	retry        retryPolicy
+	readOnly     bool
+	lockTimeout  time.Duration
 }
 
 func (p *AuditLogFilterProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -155,6 +190,55 @@ func (p *AuditLogFilterProvider) Schema(ctx context.Context, req provider.Schema
 				Description: "MySQL session lock_wait_timeout in seconds (metadata lock timeout). Defaults to 60. May also be provided via MYSQL_LOCK_WAIT_TIMEOUT environment variable.",
 				Optional:    true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times a statement failing with a transient error (lock wait timeout, deadlock, lost connection) is retried. 0 disables retries. Defaults to 5. May also be provided via MYSQL_MAX_RETRIES environment variable.",
+				Optional:    true,
+			},
+			"retry_initial_delay": schema.StringAttribute{
+				Description: "Delay before the first retry, as a duration such as '100ms'. The delay doubles with each retry, with random jitter. Defaults to '100ms'. May also be provided via MYSQL_RETRY_INITIAL_DELAY environment variable.",
+				Optional:    true,
+			},
+			"retry_max_delay": schema.StringAttribute{
+				Description: "Upper bound on the delay between retries, as a duration such as '5s'. Defaults to '5s'. May also be provided via MYSQL_RETRY_MAX_DELAY environment variable.",
+				Optional:    true,
+			},
+			"named_lock_timeout": schema.Int64Attribute{
+				Description: "Seconds to wait for the named lock that serializes changes to a filter and its assignments across Terraform runs. Defaults to 60. May also be provided via MYSQL_NAMED_LOCK_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"conn_max_lifetime": schema.StringAttribute{
+				Description: "Maximum time a connection is reused, as a duration such as '5m'. '0s' reuses connections forever. Defaults to '5m'. May also be provided via MYSQL_CONN_MAX_LIFETIME environment variable.",
+				Optional:    true,
+			},
+			"max_open_conns": schema.Int64Attribute{
+				Description: "Maximum number of open connections. 0 means unlimited. Defaults to 5. May also be provided via MYSQL_MAX_OPEN_CONNS environment variable.",
+				Optional:    true,
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open. 0 keeps none. Defaults to 5. May also be provided via MYSQL_MAX_IDLE_CONNS environment variable.",
+				Optional:    true,
+			},
+			"connect_timeout": schema.StringAttribute{
+				Description: "Timeout for establishing a connection, as a duration such as '10s'. '0s' uses the operating system's default. Defaults to '0s'. May also be provided via MYSQL_CONNECT_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"read_timeout": schema.StringAttribute{
+				Description: "I/O read timeout, as a duration such as '30s'. '0s' disables it. Defaults to '0s'. May also be provided via MYSQL_READ_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"write_timeout": schema.StringAttribute{
+				Description: "I/O write timeout, as a duration such as '30s'. '0s' disables it. Defaults to '0s'. May also be provided via MYSQL_WRITE_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"connection_params": schema.MapAttribute{
+				Description: "Additional DSN parameters passed to the MySQL driver, such as 'charset' or session system variables like 'sql_mode'. String values of system variables must be quoted, for example \"'TRADITIONAL'\". Parameters the provider manages, such as 'wait_timeout', 'tls' or 'readTimeout', are rejected; use their provider attributes instead. Other driver options, such as 'multiStatements', are rejected too: only 'charset', 'collation' and session system variables are allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse every create, update and delete, for pipelines that only run terraform plan with an account limited to SELECT. Reads, imports, data sources and plan-time checks keep working. Defaults to false. May also be provided via MYSQL_READ_ONLY environment variable.",
+				Optional:    true,
+			},
 		},
 		MarkdownDescription: "The Audit Log Filter provider manages Percona Server 8.4+ audit log filters and user assignments. " +
 			"It provides resources to create, modify, and remove audit log filters using the audit_log_filter component functions.",
@@ -171,9 +255,27 @@ func (p *AuditLogFilterProvider) Configure(ctx context.Context, req provider.Con
 	}
 
 	// Close any prior connection on reconfigure to avoid leaks.
-	if p.db != nil {
-		_ = p.db.Close()
-		p.db = nil
+	if p.client != nil {
+		_ = p.client.Close()
+		p.client = nil
+	}
+
+	// Connection values that refer to a server created in the same run are
+	// unknown until apply. Connecting now would reach the wrong server, so the
+	// provider defers its resources when Terraform supports it, and otherwise
+	// plans them without connecting.
+	if unknown := unknownConfigAttributes(data); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Provider Configuration Unknown",
+			"The provider attributes "+strings.Join(unknown, ", ")+" are not known until apply, so the provider does not connect during this plan. "+
+				"Resources keep their prior state and skip live server checks, and data sources cannot be read. "+
+				"Run Terraform with deferred actions enabled, or create the server in an earlier apply, to plan against it.",
+		)
+		return
 	}
 
 	rawConfig := loadRawConfig(data)
@@ -196,14 +298,65 @@ func (p *AuditLogFilterProvider) Configure(ctx context.Context, req provider.Con
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	db, ok := connectAndVerify(ctx, validatedConfig, &resp.Diagnostics)
-	if !ok {
-		return
+	// The connection is opened and verified by the first operation that
+	// needs it, so that validation, imports and provider functions work
+	// without a reachable server.
+	client := newMySQLClient(validatedConfig)
+
+	p.client = client
+	resp.DataSourceData = client
+	resp.ResourceData = client
+	resp.ListResourceData = client
+}
+
+// unknownConfigAttributes returns the names of the provider attributes whose
+// values are unknown, in schema order.
+func unknownConfigAttributes(data AuditLogFilterProviderModel) []string {
+	values := []struct {
+		name  string
+		value attr.Value
+	}{
+		{"endpoint", data.Endpoint},
+		{"username", data.Username},
+		{"password", data.Password},
+		{"database", data.Database},
+		{"tls", data.TLS},
+		{"tls_ca_file", data.TLSCAFile},
+		{"tls_cert_file", data.TLSCertFile},
+		{"tls_key_file", data.TLSKeyFile},
+		{"tls_server_name", data.TLSServerName},
+		{"tls_skip_verify", data.TLSSkipVerify},
+		{"wait_timeout", data.WaitTimeout},
+		{"innodb_lock_wait_timeout", data.InnodbLockWaitTimeout},
+		{"lock_wait_timeout", data.LockWaitTimeout},
+		{"max_retries", data.MaxRetries},
+		{"retry_initial_delay", data.RetryInitialDelay},
+		{"retry_max_delay", data.RetryMaxDelay},
+		{"read_only", data.ReadOnly},
+		{"named_lock_timeout", data.NamedLockTimeout},
+		{"conn_max_lifetime", data.ConnMaxLifetime},
+		{"max_open_conns", data.MaxOpenConns},
+		{"max_idle_conns", data.MaxIdleConns},
+		{"connect_timeout", data.ConnectTimeout},
+		{"read_timeout", data.ReadTimeout},
+		{"write_timeout", data.WriteTimeout},
+		{"connection_params", data.ConnectionParams},
+	}
+
+	var unknown []string
+	for _, v := range values {
+		if v.value.IsUnknown() {
+			unknown = append(unknown, v.name)
+		}
 	}
 
-	p.db = db
-	resp.DataSourceData = db
-	resp.ResourceData = db
+	// A known map can still hold unknown values.
+	for key, value := range data.ConnectionParams.Elements() {
+		if value.IsUnknown() {
+			unknown = append(unknown, fmt.Sprintf("connection_params[%q]", key))
+		}
+	}
+	return unknown
 }
 
 func loadRawConfig(data AuditLogFilterProviderModel) providerRawConfig {
@@ -218,16 +371,30 @@ func loadRawConfig(data AuditLogFilterProviderModel) providerRawConfig {
 		tlsKeyFile:               configStringOrEnv(data.TLSKeyFile, os.Getenv("MYSQL_TLS_KEY")),
 		tlsServerName:            configStringOrEnv(data.TLSServerName, os.Getenv("MYSQL_TLS_SERVER_NAME")),
 		tlsSkipVerifyEnv:         os.Getenv("MYSQL_TLS_SKIP_VERIFY"),
-		connMaxLifetimeEnv:       os.Getenv("MYSQL_CONN_MAX_LIFETIME"),
+		connMaxLifetime:          configStringOrEnv(data.ConnMaxLifetime, os.Getenv("MYSQL_CONN_MAX_LIFETIME")),
 		maxOpenConnsEnv:          os.Getenv("MYSQL_MAX_OPEN_CONNS"),
 		maxIdleConnsEnv:          os.Getenv("MYSQL_MAX_IDLE_CONNS"),
 		waitTimeoutEnv:           os.Getenv("MYSQL_WAIT_TIMEOUT"),
 		innodbLockWaitTimeoutEnv: os.Getenv("MYSQL_INNODB_LOCK_WAIT_TIMEOUT"),
 		lockWaitTimeoutEnv:       os.Getenv("MYSQL_LOCK_WAIT_TIMEOUT"),
+		maxRetriesEnv:            os.Getenv("MYSQL_MAX_RETRIES"),
+		retryInitialDelay:        configStringOrEnv(data.RetryInitialDelay, os.Getenv("MYSQL_RETRY_INITIAL_DELAY")),
+		retryMaxDelay:            configStringOrEnv(data.RetryMaxDelay, os.Getenv("MYSQL_RETRY_MAX_DELAY")),
+		readOnlyEnv:              os.Getenv("MYSQL_READ_ONLY"),
+		namedLockTimeoutEnv:      os.Getenv("MYSQL_NAMED_LOCK_TIMEOUT"),
+		connectTimeout:           configStringOrEnv(data.ConnectTimeout, os.Getenv("MYSQL_CONNECT_TIMEOUT")),
+		readTimeout:              configStringOrEnv(data.ReadTimeout, os.Getenv("MYSQL_READ_TIMEOUT")),
+		writeTimeout:             configStringOrEnv(data.WriteTimeout, os.Getenv("MYSQL_WRITE_TIMEOUT")),
+		connectionParams:         configStringMap(data.ConnectionParams),
 		tlsSkipVerify:            data.TLSSkipVerify,
 		waitTimeout:              data.WaitTimeout,
 		innodbLockWaitTimeout:    data.InnodbLockWaitTimeout,
 		lockWaitTimeout:          data.LockWaitTimeout,
+		maxRetries:               data.MaxRetries,
+		readOnly:                 data.ReadOnly,
+		namedLockTimeout:         data.NamedLockTimeout,
+		maxOpenConns:             data.MaxOpenConns,
+		maxIdleConns:             data.MaxIdleConns,
 	}
 }
 
@@ -290,23 +457,12 @@ func parseAndValidateProviderConfig(raw providerRawConfig, diagnostics *diag.Dia
 		tlsConfig = registeredName
 	}
 
-	maxLifetime, ok := parseDurationEnvOrDefault(raw.connMaxLifetimeEnv, 5*time.Minute, diagnostics,
-		"Invalid MySQL Connection Lifetime",
-		"MYSQL_CONN_MAX_LIFETIME must be a valid duration (e.g. 5m, 30s, 1h)")
-	if !ok {
-		return providerValidatedConfig{}, false
-	}
-
-	maxOpen, ok := parseNonNegativeIntEnvOrDefault(raw.maxOpenConnsEnv, 5, diagnostics,
-		"Invalid MySQL Max Open Conns",
-		"MYSQL_MAX_OPEN_CONNS must be a non-negative integer")
+	pool, ok := parsePoolConfig(raw, diagnostics)
 	if !ok {
 		return providerValidatedConfig{}, false
 	}
 
-	maxIdle, ok := parseNonNegativeIntEnvOrDefault(raw.maxIdleConnsEnv, 5, diagnostics,
-		"Invalid MySQL Max Idle Conns",
-		"MYSQL_MAX_IDLE_CONNS must be a non-negative integer")
+	params, ok := parseConnectionParams(raw.connectionParams, diagnostics)
 	if !ok {
 		return providerValidatedConfig{}, false
 	}
@@ -350,6 +506,44 @@ func parseAndValidateProviderConfig(raw providerRawConfig, diagnostics *diag.Dia
 		return providerValidatedConfig{}, false
 	}
 
+	namedLockTimeout, ok := parseTimeoutConfig(timeoutValidationInput{
+		envValue:      raw.namedLockTimeoutEnv,
+		attr:          raw.namedLockTimeout,
+		defaultValue:  defaultNamedLockTimeout,
+		summary:       "Invalid Named Lock Timeout",
+		envFieldName:  "MYSQL_NAMED_LOCK_TIMEOUT",
+		attrFieldName: "named_lock_timeout",
+		diagnostics:   diagnostics,
+	})
+	if !ok {
+		return providerValidatedConfig{}, false
+	}
+
+	params["wait_timeout"] = strconv.FormatInt(waitTimeout, 10)
+	params["innodb_lock_wait_timeout"] = strconv.FormatInt(innodbLockWaitTimeout, 10)
+	params["lock_wait_timeout"] = strconv.FormatInt(lockWaitTimeout, 10)
+
+	retry, ok := parseRetryConfig(raw, diagnostics)
+	if !ok {
+		return providerValidatedConfig{}, false
+	}
+
+	readOnly := false
+	if raw.readOnlyEnv != "" {
+		parsed, err := strconv.ParseBool(raw.readOnlyEnv)
+		if err != nil {
+			diagnostics.AddError(
+				"Invalid Read Only",
+				"MYSQL_READ_ONLY must be a boolean: "+err.Error(),
+			)
+			return providerValidatedConfig{}, false
+		}
+		readOnly = parsed
+	}
+	if !raw.readOnly.IsNull() {
+		readOnly = raw.readOnly.ValueBool()
+	}
+
 	return providerValidatedConfig{
 		mysqlConfig: mysql.Config{
 			User:                 username,
@@ -361,15 +555,17 @@ func parseAndValidateProviderConfig(raw providerRawConfig, diagnostics *diag.Dia
 			ParseTime:            true,
 			TLSConfig:            tlsConfig,
 			InterpolateParams:    true,
-			Params: map[string]string{
-				"wait_timeout":             strconv.FormatInt(waitTimeout, 10),
-				"innodb_lock_wait_timeout": strconv.FormatInt(innodbLockWaitTimeout, 10),
-				"lock_wait_timeout":        strconv.FormatInt(lockWaitTimeout, 10),
-			},
+			Timeout:              pool.connectTimeout,
+			ReadTimeout:          pool.readTimeout,
+			WriteTimeout:         pool.writeTimeout,
+			Params:               params,
 		},
-		maxLifetime:  maxLifetime,
-		maxOpenConns: maxOpen,
-		maxIdleConns: maxIdle,
+		maxLifetime:  pool.maxLifetime,
+		maxOpenConns: pool.maxOpenConns,
+		maxIdleConns: pool.maxIdleConns,
+		retry:        retry,
+		readOnly:     readOnly,
+		lockTimeout:  time.Duration(namedLockTimeout) * time.Second,
 	}, true
 }
 
@@ -414,19 +610,84 @@ func connectAndVerify(ctx context.Context, validated providerValidatedConfig, di
 		return nil, false
 	}
 
+	if !verifyPrivileges(ctx, db, requiredPrivileges(validated.readOnly, validated.mysqlConfig.DBName), diagnostics) {
+		_ = db.Close()
+		return nil, false
+	}
+
 	return db, true
 }
 
+// verifyPrivileges checks the grants of the provider account up front, so
+// that applies do not fail halfway through on a missing privilege. Privileges
+// only some features need are reported as a warning. When the grants cannot
+// be read the check is skipped with a warning.
+func verifyPrivileges(ctx context.Context, db *sql.DB, required []privilegeRequirement, diagnostics *diag.Diagnostics) bool {
+	statements, err := queryGrantsFunc(ctx, db)
+	if err != nil {
+		diagnostics.AddWarning(
+			"Unable to Verify Privileges",
+			"Could not read the grants of the provider account, so missing privileges will only be reported when an operation fails: "+err.Error(),
+		)
+		return true
+	}
+
+	var missing, missingForFeatures []string
+	for _, requirement := range parseGrants(statements).missingPrivileges(required) {
+		if requirement.feature != "" {
+			missingForFeatures = append(missingForFeatures, "  - "+requirement.String())
+		} else {
+			missing = append(missing, "  - "+requirement.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		diagnostics.AddError(
+			"Missing Privileges",
+			"The account the provider connects as is missing privileges it needs to manage audit log filters:\n\n"+
+				strings.Join(missing, "\n")+"\n\n"+
+				"Grant them with GRANT <privilege> ON <target> TO <account>, or connect as an account that has them, and run Terraform again.",
+		)
+	}
+	if len(missingForFeatures) > 0 {
+		diagnostics.AddWarning(
+			"Missing Privileges for Some Features",
+			"The account the provider connects as is missing privileges that only the features in parentheses need:\n\n"+
+				strings.Join(missingForFeatures, "\n")+"\n\n"+
+				"Configurations that do not use these features are unaffected. Otherwise grant them with GRANT <privilege> ON <target> TO <account>.",
+		)
+	}
+	return len(missing) == 0
+}
+
 func (p *AuditLogFilterProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAuditLogFilterResource,
 		NewAuditLogUserAssignmentResource,
+		NewAuditLogRoleAssignmentResource,
+		NewAuditLogPatternAssignmentResource,
+	}
+}
+
+func (p *AuditLogFilterProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewAuditLogFilterListResource,
+		NewAuditLogUserAssignmentListResource,
 	}
 }
 
 func (p *AuditLogFilterProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// Data sources can be added here if needed
+		NewAuditLogMergedDefinitionDataSource,
+		NewAuditLogEffectiveFilterDataSource,
+		NewAuditLogCoverageDataSource,
+	}
+}
+
+func (p *AuditLogFilterProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewLintDefinitionFunction,
+		NewDescribeDefinitionFunction,
 	}
 }
 
@@ -478,6 +739,17 @@ func configStringOrEnv(attr types.String, envValue string) string {
 	return envValue
 }
 
+// configStringMap returns the known, non-null values of a map of strings.
+func configStringMap(attr types.Map) map[string]string {
+	values := map[string]string{}
+	for key, value := range attr.Elements() {
+		if s, ok := value.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values[key] = s.ValueString()
+		}
+	}
+	return values
+}
+
 type timeoutValidationInput struct {
 	envValue      string
 	attr          types.Int64
@@ -525,6 +797,209 @@ func parseTimeoutConfig(input timeoutValidationInput) (int64, bool) {
 	return resolved, true
 }
 
+func parseRetryConfig(raw providerRawConfig, diagnostics *diag.Diagnostics) (retryPolicy, bool) {
+	retry := defaultRetryPolicy()
+
+	maxRetries, ok := parseNonNegativeIntEnvOrDefault(raw.maxRetriesEnv, retry.maxRetries, diagnostics,
+		"Invalid Max Retries",
+		"MYSQL_MAX_RETRIES must be a non-negative integer")
+	if !ok {
+		return retryPolicy{}, false
+	}
+	if !raw.maxRetries.IsNull() {
+		if raw.maxRetries.ValueInt64() < 0 {
+			diagnostics.AddError("Invalid Max Retries", "max_retries must be a non-negative integer.")
+			return retryPolicy{}, false
+		}
+		maxRetries = int(raw.maxRetries.ValueInt64())
+	}
+	retry.maxRetries = maxRetries
+
+	initialDelay, ok := parseDurationEnvOrDefault(raw.retryInitialDelay, retry.initialDelay, diagnostics,
+		"Invalid Retry Initial Delay",
+		"retry_initial_delay or MYSQL_RETRY_INITIAL_DELAY must be a valid duration (e.g. 100ms, 1s)")
+	if !ok {
+		return retryPolicy{}, false
+	}
+
+	maxDelay, ok := parseDurationEnvOrDefault(raw.retryMaxDelay, retry.maxDelay, diagnostics,
+		"Invalid Retry Max Delay",
+		"retry_max_delay or MYSQL_RETRY_MAX_DELAY must be a valid duration (e.g. 5s, 1m)")
+	if !ok {
+		return retryPolicy{}, false
+	}
+
+	if initialDelay <= 0 || maxDelay < initialDelay {
+		diagnostics.AddError(
+			"Invalid Retry Delays",
+			fmt.Sprintf("retry_initial_delay must be positive and no larger than retry_max_delay, got %s and %s.", initialDelay, maxDelay),
+		)
+		return retryPolicy{}, false
+	}
+	retry.initialDelay = initialDelay
+	retry.maxDelay = maxDelay
+
+	return retry, true
+}
+
+// poolConfig holds the connection pool and driver I/O settings.
+type poolConfig struct {
+	maxLifetime    time.Duration
+	maxOpenConns   int
+	maxIdleConns   int
+	connectTimeout time.Duration
+	readTimeout    time.Duration
+	writeTimeout   time.Duration
+}
+
+func parsePoolConfig(raw providerRawConfig, diagnostics *diag.Diagnostics) (poolConfig, bool) {
+	var pool poolConfig
+	var ok bool
+
+	pool.maxLifetime, ok = parseNonNegativeDuration(raw.connMaxLifetime, 5*time.Minute, diagnostics,
+		"Invalid MySQL Connection Lifetime",
+		"conn_max_lifetime or MYSQL_CONN_MAX_LIFETIME must be a non-negative duration (e.g. 5m, 30s, 1h)")
+	if !ok {
+		return poolConfig{}, false
+	}
+
+	pool.maxOpenConns, ok = parseNonNegativeIntEnvOrDefault(raw.maxOpenConnsEnv, 5, diagnostics,
+		"Invalid MySQL Max Open Conns",
+		"MYSQL_MAX_OPEN_CONNS must be a non-negative integer")
+	if !ok {
+		return poolConfig{}, false
+	}
+	if !raw.maxOpenConns.IsNull() {
+		if raw.maxOpenConns.ValueInt64() < 0 {
+			diagnostics.AddError("Invalid MySQL Max Open Conns", "max_open_conns must be a non-negative integer.")
+			return poolConfig{}, false
+		}
+		pool.maxOpenConns = int(raw.maxOpenConns.ValueInt64())
+	}
+
+	pool.maxIdleConns, ok = parseNonNegativeIntEnvOrDefault(raw.maxIdleConnsEnv, 5, diagnostics,
+		"Invalid MySQL Max Idle Conns",
+		"MYSQL_MAX_IDLE_CONNS must be a non-negative integer")
+	if !ok {
+		return poolConfig{}, false
+	}
+	if !raw.maxIdleConns.IsNull() {
+		if raw.maxIdleConns.ValueInt64() < 0 {
+			diagnostics.AddError("Invalid MySQL Max Idle Conns", "max_idle_conns must be a non-negative integer.")
+			return poolConfig{}, false
+		}
+		pool.maxIdleConns = int(raw.maxIdleConns.ValueInt64())
+	}
+
+	pool.connectTimeout, ok = parseNonNegativeDuration(raw.connectTimeout, 0, diagnostics,
+		"Invalid Connect Timeout",
+		"connect_timeout or MYSQL_CONNECT_TIMEOUT must be a non-negative duration (e.g. 10s)")
+	if !ok {
+		return poolConfig{}, false
+	}
+
+	pool.readTimeout, ok = parseNonNegativeDuration(raw.readTimeout, 0, diagnostics,
+		"Invalid Read Timeout",
+		"read_timeout or MYSQL_READ_TIMEOUT must be a non-negative duration (e.g. 30s)")
+	if !ok {
+		return poolConfig{}, false
+	}
+
+	pool.writeTimeout, ok = parseNonNegativeDuration(raw.writeTimeout, 0, diagnostics,
+		"Invalid Write Timeout",
+		"write_timeout or MYSQL_WRITE_TIMEOUT must be a non-negative duration (e.g. 30s)")
+	if !ok {
+		return poolConfig{}, false
+	}
+
+	return pool, true
+}
+
+// managedConnectionParams are the DSN parameters the provider sets itself,
+// either as session variables or as driver options, mapped to the attribute
+// that configures them.
+var managedConnectionParams = map[string]string{
+	"wait_timeout":             "wait_timeout",
+	"innodb_lock_wait_timeout": "innodb_lock_wait_timeout",
+	"lock_wait_timeout":        "lock_wait_timeout",
+	"timeout":                  "connect_timeout",
+	"readtimeout":              "read_timeout",
+	"writetimeout":             "write_timeout",
+	"tls":                      "tls",
+	"parsetime":                "",
+	"interpolateparams":        "",
+	"allownativepasswords":     "",
+}
+
+// driverConnectionParams are the other DSN parameters go-sql-driver/mysql
+// reads as driver options rather than session variables. Apart from charset
+// and collation they change how statements are sent or how results are
+// read, so connection_params must not set them.
+var driverConnectionParams = map[string]bool{
+	"allowallfiles":            true,
+	"allowcleartextpasswords":  true,
+	"allowfallbacktoplaintext": true,
+	"allowoldpasswords":        true,
+	"checkconnliveness":        true,
+	"clientfoundrows":          true,
+	"columnswithalias":         true,
+	"compress":                 true,
+	"connectionattributes":     true,
+	"loc":                      true,
+	"maxallowedpacket":         true,
+	"multistatements":          true,
+	"rejectreadonly":           true,
+	"serverpubkey":             true,
+	"strict":                   true,
+	"timetruncate":             true,
+}
+
+// parseConnectionParams validates connection_params and returns a copy the
+// managed parameters can be added to. Only charset, collation and session
+// system variables are accepted. Names are compared case-insensitively, as
+// MySQL does for system variables.
+func parseConnectionParams(params map[string]string, diagnostics *diag.Diagnostics) (map[string]string, bool) {
+	validated := make(map[string]string, len(params)+3)
+	for name, value := range params {
+		key := strings.ToLower(name)
+		attribute, managed := managedConnectionParams[key]
+		switch {
+		case name == "":
+			diagnostics.AddError("Invalid Connection Parameters",
+				"connection_params keys must not be empty.")
+			return nil, false
+		case managed && attribute != "":
+			diagnostics.AddError("Invalid Connection Parameters",
+				fmt.Sprintf("connection_params must not set %q, which the provider manages. Use the %s attribute instead.", name, attribute))
+			return nil, false
+		case managed:
+			diagnostics.AddError("Invalid Connection Parameters",
+				fmt.Sprintf("connection_params must not set %q, which the provider requires.", name))
+			return nil, false
+		case driverConnectionParams[key]:
+			diagnostics.AddError("Invalid Connection Parameters",
+				fmt.Sprintf("connection_params must not set the driver option %q. Only charset, collation and session system variables are allowed.", name))
+			return nil, false
+		}
+		validated[name] = value
+	}
+	return validated, true
+}
+
+// parseNonNegativeDuration parses a duration setting, returning defaultValue
+// when it is unset.
+func parseNonNegativeDuration(value string, defaultValue time.Duration, diagnostics *diag.Diagnostics, summary, requirement string) (time.Duration, bool) {
+	parsed, ok := parseDurationEnvOrDefault(value, defaultValue, diagnostics, summary, requirement)
+	if !ok {
+		return 0, false
+	}
+	if parsed < 0 {
+		diagnostics.AddError(summary, requirement+".")
+		return 0, false
+	}
+	return parsed, true
+}
+
 func parseDurationEnvOrDefault(envValue string, defaultValue time.Duration, diagnostics *diag.Diagnostics, summary, requirement string) (time.Duration, bool) {
 	if envValue == "" {
 		return defaultValue, true
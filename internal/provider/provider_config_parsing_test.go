@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -85,6 +87,289 @@ func TestParseAndValidateProviderConfigDefaults(t *testing.T) {
 	if validated.mysqlConfig.Params["lock_wait_timeout"] != "60" {
 		t.Fatalf("unexpected lock_wait_timeout: %q", validated.mysqlConfig.Params["lock_wait_timeout"])
 	}
+	if validated.retry != defaultRetryPolicy() {
+		t.Fatalf("unexpected retry policy: %+v", validated.retry)
+	}
+	if validated.lockTimeout != defaultNamedLockTimeout*time.Second {
+		t.Fatalf("unexpected named lock timeout: %s", validated.lockTimeout)
+	}
+}
+
+func TestParseAndValidateProviderConfigRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         providerRawConfig
+		want        retryPolicy
+		wantSummary string
+	}{
+		{
+			name: "attribute_overrides_env",
+			raw: providerRawConfig{
+				maxRetriesEnv:     "2",
+				maxRetries:        types.Int64Value(0),
+				retryInitialDelay: "50ms",
+				retryMaxDelay:     "1s",
+			},
+			want: retryPolicy{maxRetries: 0, initialDelay: 50 * time.Millisecond, maxDelay: time.Second},
+		},
+		{
+			name:        "negative_max_retries",
+			raw:         providerRawConfig{maxRetries: types.Int64Value(-1)},
+			wantSummary: "Invalid Max Retries",
+		},
+		{
+			name:        "invalid_env_max_retries",
+			raw:         providerRawConfig{maxRetriesEnv: "many"},
+			wantSummary: "Invalid Max Retries",
+		},
+		{
+			name:        "invalid_duration",
+			raw:         providerRawConfig{retryMaxDelay: "soon"},
+			wantSummary: "Invalid Retry Max Delay",
+		},
+		{
+			name:        "initial_delay_above_max",
+			raw:         providerRawConfig{retryInitialDelay: "10s", retryMaxDelay: "1s"},
+			wantSummary: "Invalid Retry Delays",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			validated, ok := parseAndValidateProviderConfig(tc.raw, &diagnostics)
+			if tc.wantSummary != "" {
+				if ok || !diagnostics.HasError() {
+					t.Fatalf("expected parse to fail")
+				}
+				if diagnostics[0].Summary() != tc.wantSummary {
+					t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("expected parse to succeed, diagnostics: %+v", diagnostics)
+			}
+			if validated.retry != tc.want {
+				t.Fatalf("unexpected retry policy: got %+v, want %+v", validated.retry, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseAndValidateProviderConfigPool(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         providerRawConfig
+		want        poolConfig
+		wantSummary string
+	}{
+		{
+			name: "defaults",
+			raw:  providerRawConfig{},
+			want: poolConfig{maxLifetime: 5 * time.Minute, maxOpenConns: 5, maxIdleConns: 5},
+		},
+		{
+			name: "attributes_override_env",
+			raw: providerRawConfig{
+				connMaxLifetime: "30s",
+				maxOpenConnsEnv: "7",
+				maxOpenConns:    types.Int64Value(0),
+				maxIdleConnsEnv: "3",
+				maxIdleConns:    types.Int64Value(2),
+				connectTimeout:  "10s",
+				readTimeout:     "1m",
+				writeTimeout:    "20s",
+			},
+			want: poolConfig{
+				maxLifetime:    30 * time.Second,
+				maxOpenConns:   0,
+				maxIdleConns:   2,
+				connectTimeout: 10 * time.Second,
+				readTimeout:    time.Minute,
+				writeTimeout:   20 * time.Second,
+			},
+		},
+		{
+			name:        "negative_max_open_conns",
+			raw:         providerRawConfig{maxOpenConns: types.Int64Value(-1)},
+			wantSummary: "Invalid MySQL Max Open Conns",
+		},
+		{
+			name:        "invalid_env_max_idle_conns",
+			raw:         providerRawConfig{maxIdleConnsEnv: "few"},
+			wantSummary: "Invalid MySQL Max Idle Conns",
+		},
+		{
+			name:        "negative_lifetime",
+			raw:         providerRawConfig{connMaxLifetime: "-1m"},
+			wantSummary: "Invalid MySQL Connection Lifetime",
+		},
+		{
+			name:        "invalid_read_timeout",
+			raw:         providerRawConfig{readTimeout: "slow"},
+			wantSummary: "Invalid Read Timeout",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			validated, ok := parseAndValidateProviderConfig(tc.raw, &diagnostics)
+			if tc.wantSummary != "" {
+				if ok || !diagnostics.HasError() {
+					t.Fatalf("expected parse to fail")
+				}
+				if diagnostics[0].Summary() != tc.wantSummary {
+					t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("expected parse to succeed, diagnostics: %+v", diagnostics)
+			}
+			got := poolConfig{
+				maxLifetime:    validated.maxLifetime,
+				maxOpenConns:   validated.maxOpenConns,
+				maxIdleConns:   validated.maxIdleConns,
+				connectTimeout: validated.mysqlConfig.Timeout,
+				readTimeout:    validated.mysqlConfig.ReadTimeout,
+				writeTimeout:   validated.mysqlConfig.WriteTimeout,
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected pool config: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseAndValidateProviderConfigConnectionParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[string]string
+		wantErr string
+	}{
+		{
+			name:   "session_variable",
+			params: map[string]string{"sql_mode": "'TRADITIONAL'", "charset": "utf8mb4"},
+		},
+		{
+			name:    "managed_timeout",
+			params:  map[string]string{"Wait_Timeout": "5"},
+			wantErr: "Use the wait_timeout attribute instead",
+		},
+		{
+			name:    "driver_timeout",
+			params:  map[string]string{"readTimeout": "1s"},
+			wantErr: "Use the read_timeout attribute instead",
+		},
+		{
+			name:    "required_option",
+			params:  map[string]string{"parseTime": "false"},
+			wantErr: "which the provider requires",
+		},
+		{
+			name:    "multi_statements",
+			params:  map[string]string{"multiStatements": "true"},
+			wantErr: `driver option "multiStatements"`,
+		},
+		{
+			name:    "allow_all_files",
+			params:  map[string]string{"allowAllFiles": "true"},
+			wantErr: "Only charset, collation and session system variables are allowed",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			validated, ok := parseAndValidateProviderConfig(providerRawConfig{connectionParams: tc.params}, &diagnostics)
+			if tc.wantErr != "" {
+				if ok || diagnostics.ErrorsCount() != 1 {
+					t.Fatalf("expected one diagnostic error, got: %+v", diagnostics)
+				}
+				if diagnostics[0].Summary() != "Invalid Connection Parameters" {
+					t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+				}
+				if !strings.Contains(diagnostics[0].Detail(), tc.wantErr) {
+					t.Fatalf("detail %q does not contain %q", diagnostics[0].Detail(), tc.wantErr)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("expected parse to succeed, diagnostics: %+v", diagnostics)
+			}
+			for name, value := range tc.params {
+				if validated.mysqlConfig.Params[name] != value {
+					t.Fatalf("param %s = %q, want %q", name, validated.mysqlConfig.Params[name], value)
+				}
+			}
+			if validated.mysqlConfig.Params["wait_timeout"] != "10000" {
+				t.Fatalf("managed wait_timeout lost: %q", validated.mysqlConfig.Params["wait_timeout"])
+			}
+		})
+	}
+}
+
+func TestParseAndValidateProviderConfigReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		raw         providerRawConfig
+		want        bool
+		wantSummary string
+	}{
+		{name: "default", raw: providerRawConfig{}, want: false},
+		{name: "env", raw: providerRawConfig{readOnlyEnv: "true"}, want: true},
+		{name: "attribute_overrides_env", raw: providerRawConfig{readOnlyEnv: "true", readOnly: types.BoolValue(false)}, want: false},
+		{name: "attribute", raw: providerRawConfig{readOnly: types.BoolValue(true)}, want: true},
+		{name: "invalid_env", raw: providerRawConfig{readOnlyEnv: "sometimes"}, wantSummary: "Invalid Read Only"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var diagnostics diag.Diagnostics
+			validated, ok := parseAndValidateProviderConfig(tc.raw, &diagnostics)
+			if tc.wantSummary != "" {
+				if ok || !diagnostics.HasError() {
+					t.Fatalf("expected parse to fail")
+				}
+				if diagnostics[0].Summary() != tc.wantSummary {
+					t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("expected parse to succeed, diagnostics: %+v", diagnostics)
+			}
+			if validated.readOnly != tc.want {
+				t.Fatalf("readOnly = %t, want %t", validated.readOnly, tc.want)
+			}
+		})
+	}
 }
 
 func TestParseAndValidateProviderConfigInvalidWaitTimeout(t *testing.T) {
@@ -110,6 +395,26 @@ func TestParseAndValidateProviderConfigInvalidWaitTimeout(t *testing.T) {
 	}
 }
 
+func TestParseAndValidateProviderConfigInvalidNamedLockTimeout(t *testing.T) {
+	t.Parallel()
+
+	raw := providerRawConfig{
+		namedLockTimeoutEnv: "0",
+	}
+	var diagnostics diag.Diagnostics
+
+	_, ok := parseAndValidateProviderConfig(raw, &diagnostics)
+	if ok {
+		t.Fatalf("expected parse to fail for invalid named lock timeout")
+	}
+	if diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one diagnostic error, got %d", diagnostics.ErrorsCount())
+	}
+	if diagnostics[0].Summary() != "Invalid Named Lock Timeout" {
+		t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+	}
+}
+
 func TestParseAndValidateProviderConfigTLSConflict(t *testing.T) {
 	t.Parallel()
 
@@ -4,20 +4,28 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestConnectAndVerifyOpenError(t *testing.T) {
 	originalOpen := sqlOpenFunc
 	originalPing := pingDBFunc
 	originalQuery := queryComponentCountFunc
+	originalGrants := queryGrantsFunc
 	t.Cleanup(func() {
 		sqlOpenFunc = originalOpen
 		pingDBFunc = originalPing
 		queryComponentCountFunc = originalQuery
+		queryGrantsFunc = originalGrants
 	})
 
 	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
@@ -41,10 +49,12 @@ func TestConnectAndVerifyPingError(t *testing.T) {
 	originalOpen := sqlOpenFunc
 	originalPing := pingDBFunc
 	originalQuery := queryComponentCountFunc
+	originalGrants := queryGrantsFunc
 	t.Cleanup(func() {
 		sqlOpenFunc = originalOpen
 		pingDBFunc = originalPing
 		queryComponentCountFunc = originalQuery
+		queryGrantsFunc = originalGrants
 	})
 
 	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
@@ -71,10 +81,12 @@ func TestConnectAndVerifyComponentMissing(t *testing.T) {
 	originalOpen := sqlOpenFunc
 	originalPing := pingDBFunc
 	originalQuery := queryComponentCountFunc
+	originalGrants := queryGrantsFunc
 	t.Cleanup(func() {
 		sqlOpenFunc = originalOpen
 		pingDBFunc = originalPing
 		queryComponentCountFunc = originalQuery
+		queryGrantsFunc = originalGrants
 	})
 
 	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
@@ -104,10 +116,12 @@ func TestConnectAndVerifySuccess(t *testing.T) {
 	originalOpen := sqlOpenFunc
 	originalPing := pingDBFunc
 	originalQuery := queryComponentCountFunc
+	originalGrants := queryGrantsFunc
 	t.Cleanup(func() {
 		sqlOpenFunc = originalOpen
 		pingDBFunc = originalPing
 		queryComponentCountFunc = originalQuery
+		queryGrantsFunc = originalGrants
 	})
 
 	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
@@ -119,6 +133,9 @@ func TestConnectAndVerifySuccess(t *testing.T) {
 	queryComponentCountFunc = func(ctx context.Context, db *sql.DB) (int, error) {
 		return 1, nil
 	}
+	queryGrantsFunc = func(ctx context.Context, db *sql.DB) ([]string, error) {
+		return []string{"GRANT SELECT ON *.* TO `tf`@`%`", "GRANT AUDIT_ADMIN ON *.* TO `tf`@`%`"}, nil
+	}
 
 	validated := providerValidatedConfig{
 		maxLifetime:  2 * time.Minute,
@@ -139,3 +156,188 @@ func TestConnectAndVerifySuccess(t *testing.T) {
 	}
 	_ = db.Close()
 }
+
+func TestConnectAndVerifyMissingPrivileges(t *testing.T) {
+	originalOpen := sqlOpenFunc
+	originalPing := pingDBFunc
+	originalQuery := queryComponentCountFunc
+	originalGrants := queryGrantsFunc
+	t.Cleanup(func() {
+		sqlOpenFunc = originalOpen
+		pingDBFunc = originalPing
+		queryComponentCountFunc = originalQuery
+		queryGrantsFunc = originalGrants
+	})
+
+	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
+		return sql.Open("mysql", "")
+	}
+	pingDBFunc = func(ctx context.Context, db *sql.DB) error {
+		return nil
+	}
+	queryComponentCountFunc = func(ctx context.Context, db *sql.DB) (int, error) {
+		return 1, nil
+	}
+	queryGrantsFunc = func(ctx context.Context, db *sql.DB) ([]string, error) {
+		return []string{"GRANT USAGE ON *.* TO `tf`@`%`", "GRANT SELECT ON `mysql`.`audit_log_filter` TO `tf`@`%`"}, nil
+	}
+
+	var diagnostics diag.Diagnostics
+	_, ok := connectAndVerify(context.Background(), providerValidatedConfig{}, &diagnostics)
+	if ok {
+		t.Fatalf("expected connectAndVerify to fail when privileges are missing")
+	}
+	if !diagnostics.HasError() {
+		t.Fatalf("expected diagnostics error for missing privileges")
+	}
+	if diagnostics[0].Summary() != "Missing Privileges" {
+		t.Fatalf("unexpected diagnostic summary: %q", diagnostics[0].Summary())
+	}
+	for _, want := range []string{"- AUDIT_ADMIN ON *.*", "- SELECT ON mysql.audit_log_user"} {
+		if !strings.Contains(diagnostics[0].Detail(), want) {
+			t.Fatalf("expected detail to list %q, got: %s", want, diagnostics[0].Detail())
+		}
+	}
+	if strings.Contains(diagnostics[0].Detail(), "- SELECT ON mysql.audit_log_filter") {
+		t.Fatalf("expected granted privilege not to be listed, got: %s", diagnostics[0].Detail())
+	}
+	warnings := diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "Missing Privileges for Some Features" {
+		t.Fatalf("expected a warning for feature privileges, got: %+v", warnings)
+	}
+	if !strings.Contains(warnings[0].Detail(), "- SELECT ON mysql.role_edges (role_assignment)") {
+		t.Fatalf("expected warning to name the feature, got: %s", warnings[0].Detail())
+	}
+}
+
+func TestConfigureUnknownEndpoint(t *testing.T) {
+	originalOpen := sqlOpenFunc
+	t.Cleanup(func() {
+		sqlOpenFunc = originalOpen
+	})
+
+	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
+		t.Errorf("expected no connection while the endpoint is unknown")
+		return nil, errors.New("unexpected connection")
+	}
+
+	tests := []struct {
+		name            string
+		deferralAllowed bool
+		wantDeferred    bool
+	}{
+		{name: "deferral_allowed", deferralAllowed: true, wantDeferred: true},
+		{name: "deferral_not_allowed", deferralAllowed: false, wantDeferred: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := &AuditLogFilterProvider{}
+
+			var schemaResp provider.SchemaResponse
+			p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			values := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(attributeType, nil)
+			}
+			values["endpoint"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+			req := provider.ConfigureRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+				ClientCapabilities: provider.ConfigureProviderClientCapabilities{
+					DeferralAllowed: tc.deferralAllowed,
+				},
+			}
+			var resp provider.ConfigureResponse
+			p.Configure(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("expected no errors, got: %+v", resp.Diagnostics)
+			}
+			if (resp.Deferred != nil) != tc.wantDeferred {
+				t.Fatalf("deferred = %+v, want deferred %t", resp.Deferred, tc.wantDeferred)
+			}
+			if tc.wantDeferred && resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+				t.Fatalf("unexpected deferral reason: %v", resp.Deferred.Reason)
+			}
+			if !tc.wantDeferred && resp.Diagnostics.WarningsCount() != 1 {
+				t.Fatalf("expected one warning, got: %+v", resp.Diagnostics)
+			}
+			if resp.ResourceData != nil || resp.DataSourceData != nil {
+				t.Fatalf("expected no provider data while the endpoint is unknown")
+			}
+		})
+	}
+}
+
+func TestImportStateWithoutConnection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resource resource.ResourceWithImportState
+		id       string
+	}{
+		{name: "filter", resource: &AuditLogFilterResource{}, id: "log_all"},
+		{name: "user_assignment", resource: &AuditLogUserAssignmentResource{}, id: "app@%"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			s := resourceSchema(t, tc.resource)
+			resp := resource.ImportStateResponse{
+				State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+			}
+			tc.resource.ImportState(ctx, resource.ImportStateRequest{ID: tc.id}, &resp)
+
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics[0].Summary() != "Provider Not Connected" {
+				t.Fatalf("expected a Provider Not Connected error, got: %+v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMySQLClientConnectsOnFirstUse(t *testing.T) {
+	originalOpen := sqlOpenFunc
+	t.Cleanup(func() {
+		sqlOpenFunc = originalOpen
+	})
+
+	opens := 0
+	sqlOpenFunc = func(driverName, dataSourceName string) (*sql.DB, error) {
+		opens++
+		return nil, errors.New("open failed")
+	}
+
+	client := newMySQLClient(providerValidatedConfig{})
+	if opens != 0 {
+		t.Fatalf("expected no connection before first use, got %d", opens)
+	}
+
+	var count int
+	err := client.QueryRowContext(context.Background(), "SELECT 1").Scan(&count)
+	var connErr *connectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected a connection error, got: %v", err)
+	}
+
+	if _, err := client.ExecContext(context.Background(), "DO 1"); !errors.As(err, &connErr) {
+		t.Fatalf("expected the cached connection error, got: %v", err)
+	}
+	if opens != 1 {
+		t.Fatalf("expected the failed connection to be attempted once, got %d", opens)
+	}
+
+	var diagnostics diag.Diagnostics
+	addMySQLError(&diagnostics, path.Empty(), err, "Database Error", "Failed to run statement: "+err.Error())
+	if len(diagnostics) != 1 || diagnostics[0].Summary() != "Unable to Create MySQL Client" {
+		t.Fatalf("expected the connection diagnostic, got: %+v", diagnostics)
+	}
+}
@@ -105,7 +105,7 @@ func TestAccAuditLogUserAssignmentResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("auditlogfilters_user_assignment.test", "username", "test_user"),
 					resource.TestCheckResourceAttr("auditlogfilters_user_assignment.test", "userhost", "%"),
 					resource.TestCheckResourceAttr("auditlogfilters_user_assignment.test", "filter_name", "test_assignment_filter"),
-					resource.TestCheckResourceAttr("auditlogfilters_user_assignment.test", "id", "test_user@%"),
+					resource.TestCheckResourceAttr("auditlogfilters_user_assignment.test", "id", "'test_user'@'%'"),
 				),
 			},
 			// ImportState testing
@@ -115,6 +115,13 @@ func TestAccAuditLogUserAssignmentResource_basic(t *testing.T) {
 				ImportStateId:     "test_user@%",
 				ImportStateVerify: true,
 			},
+			// Import block with resource identity
+			{
+				Config:          testAccAuditLogUserAssignmentResourceConfig("test_user", "%", "test_assignment_filter"),
+				ResourceName:    "auditlogfilters_user_assignment.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
 		},
 		CheckDestroy: testAccCheckAuditLogUserAssignmentDestroy,
 	})
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testImportIdentity(t *testing.T, r resource.ResourceWithIdentity, values map[string]string) *tfsdk.ResourceIdentity {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected identity schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	attributes := map[string]tftypes.Value{}
+	for name := range schemaResp.IdentitySchema.Attributes {
+		attributes[name] = tftypes.NewValue(tftypes.String, values[name])
+	}
+
+	return &tfsdk.ResourceIdentity{
+		Schema: schemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), attributes),
+	}
+}
+
+func TestFilterNameFromImport(t *testing.T) {
+	t.Parallel()
+
+	r := &AuditLogFilterResource{}
+	cases := []struct {
+		name string
+		req  resource.ImportStateRequest
+		want string
+	}{
+		{
+			name: "import id",
+			req:  resource.ImportStateRequest{ID: "log_all"},
+			want: "log_all",
+		},
+		{
+			name: "identity",
+			req:  resource.ImportStateRequest{Identity: testImportIdentity(t, r, map[string]string{"name": "log_all"})},
+			want: "log_all",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, diags := filterNameFromImport(context.Background(), tc.req)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tc.want {
+				t.Fatalf("filterNameFromImport() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccountFromImportIdentity(t *testing.T) {
+	t.Parallel()
+
+	r := &AuditLogUserAssignmentResource{}
+	req := resource.ImportStateRequest{
+		Identity: testImportIdentity(t, r, map[string]string{"username": "svc@corp", "userhost": "10.0.0.%"}),
+	}
+
+	username, userhost, diags := r.accountFromImport(context.Background(), req)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if username != "svc@corp" || userhost != "10.0.0.%" {
+		t.Fatalf("accountFromImport() = %q, %q; want %q, %q", username, userhost, "svc@corp", "10.0.0.%")
+	}
+}
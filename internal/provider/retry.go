@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry policy. With the default innodb_lock_wait_timeout of one
+// second, five retries ride out lock contention of several seconds.
+const (
+	defaultMaxRetries        = 5
+	defaultRetryInitialDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay     = 5 * time.Second
+)
+
+// retryableMySQLErrors are server and client error numbers after which the
+// statement did not take effect and running it again may succeed.
+var retryableMySQLErrors = map[uint16]string{
+	1040: "too many connections",
+	1158: "network read error",
+	1159: "network read timeout",
+	1205: "lock wait timeout",
+	1213: "deadlock",
+	4031: "disconnected by the server because of inactivity",
+}
+
+// ambiguousMySQLErrors are errors after which the statement may or may not
+// have taken effect, because the connection failed while the server ran it.
+// Only reads are retried after them: the audit log filter functions are not
+// idempotent, and a repeated audit_log_filter_remove_filter fails once the
+// first one has removed the filter.
+var ambiguousMySQLErrors = map[uint16]string{
+	1053: "server shutdown in progress",
+	1160: "network write error",
+	1161: "network write timeout",
+	1927: "connection killed",
+	2006: "server has gone away",
+	2013: "lost connection during query",
+}
+
+// retryPolicy retries statements that fail with transient errors, waiting an
+// exponentially growing, jittered delay between attempts.
+type retryPolicy struct {
+	maxRetries   int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+}
+
+func defaultRetryPolicy() retryPolicy {
+	return retryPolicy{
+		maxRetries:   defaultMaxRetries,
+		initialDelay: defaultRetryInitialDelay,
+		maxDelay:     defaultRetryMaxDelay,
+	}
+}
+
+// do runs op until it succeeds, fails with an error that is not retryable,
+// or the retries are used up, and returns its last error. Each retry is
+// logged with the statement that failed. Writes are not retried after errors
+// that leave it unknown whether they took effect.
+func (p retryPolicy) do(ctx context.Context, statement string, write bool, op func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= p.maxRetries {
+			return err
+		}
+
+		reason, retryable := retryableError(err, write)
+		if !retryable {
+			return err
+		}
+
+		delay := p.backoff(attempt, rand.Int64N)
+		tflog.Warn(ctx, "Retrying MySQL statement after transient error", map[string]any{
+			"statement":   statement,
+			"reason":      reason,
+			"error":       err.Error(),
+			"attempt":     attempt + 1,
+			"max_retries": p.maxRetries,
+			"delay":       delay.String(),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the delay before retry number attempt, counting from zero:
+// initialDelay doubled per attempt and capped at maxDelay, of which the upper
+// half is random so that concurrent retries spread out. random returns a value
+// in [0, n) and is rand.Int64N outside tests.
+func (p retryPolicy) backoff(attempt int, random func(n int64) int64) time.Duration {
+	delay := p.maxDelay
+	if attempt < 32 && p.initialDelay<<attempt < p.maxDelay {
+		delay = p.initialDelay << attempt
+	}
+
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + random(half+1))
+}
+
+// retryableError classifies an error as transient, returning a short reason,
+// or fatal. Cancellation is always fatal. Errors raised when the connection
+// failed while a statement ran are only transient for reads.
+func retryableError(err error, write bool) (string, bool) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		if reason, ok := retryableMySQLErrors[mysqlErr.Number]; ok {
+			return reason, true
+		}
+		reason, ok := ambiguousMySQLErrors[mysqlErr.Number]
+		return reason, ok && !write
+	}
+
+	// The driver returns driver.ErrBadConn only before it sends the
+	// statement.
+	if errors.Is(err, driver.ErrBadConn) {
+		return "bad connection", true
+	}
+
+	if errors.Is(err, mysql.ErrInvalidConn) {
+		return "invalid connection", !write
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "network error", !write
+	}
+
+	return "", false
+}
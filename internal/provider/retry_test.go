@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestRetryableError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		wantRead  bool
+		wantWrite bool
+	}{
+		{name: "lock_wait_timeout", err: &mysql.MySQLError{Number: 1205}, wantRead: true, wantWrite: true},
+		{name: "deadlock_wrapped", err: fmt.Errorf("set filter: %w", &mysql.MySQLError{Number: 1213}), wantRead: true, wantWrite: true},
+		{name: "bad_conn", err: driver.ErrBadConn, wantRead: true, wantWrite: true},
+		{name: "server_gone_away", err: &mysql.MySQLError{Number: 2006}, wantRead: true},
+		{name: "lost_connection", err: &mysql.MySQLError{Number: 2013}, wantRead: true},
+		{name: "invalid_conn", err: mysql.ErrInvalidConn, wantRead: true},
+		{name: "read_timeout", err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, wantRead: true},
+		{name: "access_denied", err: &mysql.MySQLError{Number: 1045}},
+		{name: "syntax_error", err: &mysql.MySQLError{Number: 1064}},
+		{name: "no_rows", err: sql.ErrNoRows},
+		{name: "canceled", err: context.Canceled},
+		{name: "plain_error", err: errors.New("boom")},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, got := retryableError(tc.err, false); got != tc.wantRead {
+				t.Fatalf("retryableError(%v, read) = %t, want %t", tc.err, got, tc.wantRead)
+			}
+			if _, got := retryableError(tc.err, true); got != tc.wantWrite {
+				t.Fatalf("retryableError(%v, write) = %t, want %t", tc.err, got, tc.wantWrite)
+			}
+		})
+	}
+}
+
+func TestIsWriteQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "SELECT audit_log_filter_set_filter(?, ?)", want: true},
+		{query: "SELECT audit_log_filter_remove_filter(?)", want: true},
+		{query: "SELECT Audit_Log_Filter_Set_User (?, ?)", want: true},
+		{query: "SELECT audit_log_filter_remove_user(?)", want: true},
+		{query: "SELECT filter FROM mysql.audit_log_filter WHERE name = ?", want: false},
+		{query: "SELECT CURRENT_USER()", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := isWriteQuery(tc.query); got != tc.want {
+			t.Errorf("isWriteQuery(%q) = %t, want %t", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxRetries: 10, initialDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	noJitter := func(int64) int64 { return 0 }
+	fullJitter := func(n int64) int64 { return n - 1 }
+
+	tests := []struct {
+		attempt int
+		min     time.Duration
+	}{
+		{attempt: 0, min: 50 * time.Millisecond},
+		{attempt: 1, min: 100 * time.Millisecond},
+		{attempt: 3, min: 400 * time.Millisecond},
+		{attempt: 4, min: 500 * time.Millisecond},
+		{attempt: 60, min: 500 * time.Millisecond},
+	}
+
+	for _, tc := range tests {
+		if got := policy.backoff(tc.attempt, noJitter); got != tc.min {
+			t.Fatalf("backoff(%d) without jitter = %s, want %s", tc.attempt, got, tc.min)
+		}
+		if got := policy.backoff(tc.attempt, fullJitter); got != 2*tc.min {
+			t.Fatalf("backoff(%d) with full jitter = %s, want %s", tc.attempt, got, 2*tc.min)
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	t.Parallel()
+
+	policy := retryPolicy{maxRetries: 3, initialDelay: time.Millisecond, maxDelay: time.Millisecond}
+	deadlock := &mysql.MySQLError{Number: 1213}
+	lostConnection := &mysql.MySQLError{Number: 2013}
+
+	tests := []struct {
+		name      string
+		write     bool
+		failures  []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "recovers", failures: []error{deadlock, deadlock}, wantCalls: 3},
+		{name: "gives_up", failures: []error{deadlock, deadlock, deadlock, deadlock, deadlock}, wantCalls: 4, wantErr: deadlock},
+		{name: "fatal", failures: []error{sql.ErrNoRows}, wantCalls: 1, wantErr: sql.ErrNoRows},
+		{name: "read_recovers_from_lost_connection", failures: []error{lostConnection}, wantCalls: 2},
+		{name: "write_not_repeated_after_lost_connection", write: true, failures: []error{lostConnection}, wantCalls: 1, wantErr: lostConnection},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			err := policy.do(context.Background(), "SELECT 1", tc.write, func() error {
+				calls++
+				if calls <= len(tc.failures) {
+					return tc.failures[calls-1]
+				}
+				return nil
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("do() error = %v, want %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Fatalf("do() made %d calls, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// roleEdge records that the account toUser@toHost is granted the role
+// fromUser@fromHost, either in mysql.role_edges or as a default role in
+// mysql.default_roles.
+type roleEdge struct {
+	fromUser string
+	fromHost string
+	toUser   string
+	toHost   string
+}
+
+// listRoleEdges returns every role grant and default role on the server.
+func listRoleEdges(ctx context.Context, db *mysqlClient) (edges []roleEdge, err error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT FROM_USER, FROM_HOST, TO_USER, TO_HOST FROM mysql.role_edges "+
+			"UNION SELECT DEFAULT_ROLE_USER, DEFAULT_ROLE_HOST, USER, HOST FROM mysql.default_roles")
+	if err != nil {
+		return nil, fmt.Errorf("query role edges: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close role edge rows: %w", closeErr)
+		}
+	}()
+
+	for rows.Next() {
+		var edge roleEdge
+		if err := rows.Scan(&edge.fromUser, &edge.fromHost, &edge.toUser, &edge.toHost); err != nil {
+			return nil, fmt.Errorf("scan role edge: %w", err)
+		}
+		edges = append(edges, edge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate role edges: %w", err)
+	}
+
+	return edges, nil
+}
+
+// roleGrantees returns the accounts granted a role directly or through other
+// roles, ordered by user and host. The role itself is not included, even when
+// a grant cycle leads back to it.
+func roleGrantees(edges []roleEdge, roleUser, roleHost string) []mysqlAccount {
+	role := mysqlAccount{username: roleUser, userhost: roleHost}
+	seen := map[mysqlAccount]bool{role: true}
+	queue := []mysqlAccount{role}
+	var grantees []mysqlAccount
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range edges {
+			if edge.fromUser != current.username || edge.fromHost != current.userhost {
+				continue
+			}
+			grantee := mysqlAccount{username: edge.toUser, userhost: edge.toHost}
+			if seen[grantee] {
+				continue
+			}
+			seen[grantee] = true
+			grantees = append(grantees, grantee)
+			queue = append(queue, grantee)
+		}
+	}
+
+	sort.Slice(grantees, func(i, j int) bool {
+		if grantees[i].username != grantees[j].username {
+			return grantees[i].username < grantees[j].username
+		}
+		return grantees[i].userhost < grantees[j].userhost
+	})
+	return grantees
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoleGrantees(t *testing.T) {
+	t.Parallel()
+
+	edges := []roleEdge{
+		{fromUser: "app_read", fromHost: "%", toUser: "alice", toHost: "%"},
+		{fromUser: "app_read", fromHost: "%", toUser: "app_write", toHost: "%"},
+		{fromUser: "app_write", fromHost: "%", toUser: "bob", toHost: "10.0.0.%"},
+		{fromUser: "app_write", fromHost: "%", toUser: "app_read", toHost: "%"},
+		{fromUser: "app_read", fromHost: "localhost", toUser: "carol", toHost: "%"},
+		// Default role edges may repeat a grant.
+		{fromUser: "app_read", fromHost: "%", toUser: "alice", toHost: "%"},
+	}
+
+	cases := []struct {
+		name     string
+		roleUser string
+		roleHost string
+		want     []mysqlAccount
+	}{
+		{
+			name:     "direct and nested grantees",
+			roleUser: "app_read",
+			roleHost: "%",
+			want: []mysqlAccount{
+				{username: "alice", userhost: "%"},
+				{username: "app_write", userhost: "%"},
+				{username: "bob", userhost: "10.0.0.%"},
+			},
+		},
+		{
+			name:     "role host distinguishes roles",
+			roleUser: "app_read",
+			roleHost: "localhost",
+			want:     []mysqlAccount{{username: "carol", userhost: "%"}},
+		},
+		{
+			name:     "role without grantees",
+			roleUser: "unused",
+			roleHost: "%",
+			want:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := roleGrantees(edges, tc.roleUser, tc.roleHost)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("roleGrantees() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const currentUserQuery = "SELECT CURRENT_USER()"
+
+// queryCurrentAccount returns the account the provider connection is
+// authenticated as, split into the user and host parts stored in
+// mysql.audit_log_user.
+func queryCurrentAccount(ctx context.Context, db *mysqlClient) (string, string, error) {
+	var account string
+	if err := db.QueryRowContext(ctx, currentUserQuery).Scan(&account); err != nil {
+		return "", "", err
+	}
+	username, userhost := splitCurrentUser(account)
+	return username, userhost, nil
+}
+
+// splitCurrentUser splits the user@host value returned by CURRENT_USER().
+// Host names cannot contain '@', so the last separator is used.
+func splitCurrentUser(account string) (string, string) {
+	i := strings.LastIndex(account, "@")
+	if i < 0 {
+		return account, "%"
+	}
+	return account[:i], account[i+1:]
+}
+
+// definitionAborts reports whether a filter definition contains an "abort"
+// rule that can block statements, i.e. any abort item that is not false.
+func definitionAborts(definition string) bool {
+	var parsed any
+	if err := json.Unmarshal([]byte(definition), &parsed); err != nil {
+		return false
+	}
+	return hasAbortRule(parsed)
+}
+
+func hasAbortRule(value any) bool {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, child := range typed {
+			if key == "abort" && !isFalse(child) {
+				return true
+			}
+			if hasAbortRule(child) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range typed {
+			if hasAbortRule(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterAborts reports whether a filter contains "abort" rules. The
+// definition a filter resource plans for it in this run takes precedence over
+// the one on the server, so that a filter created or changed in the same plan
+// as its assignments is checked. A filter that is neither planned nor on the
+// server does not abort.
+func filterAborts(ctx context.Context, db *mysqlClient, filterName string) (bool, error) {
+	if definition, ok := db.plannedFilterDefinition(filterName); ok {
+		return definitionAborts(definition), nil
+	}
+
+	var definition string
+	err := db.QueryRowContext(ctx, "SELECT filter FROM mysql.audit_log_filter WHERE name = ?", filterName).Scan(&definition)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return definitionAborts(definition), nil
+}
+
+// checkAccountSelfAbort fails when filterName contains "abort" rules and
+// assigning it to username@userhost would apply it to the account the
+// provider connects as: directly, or through the '%' default when that
+// account has no assignment of its own.
+func checkAccountSelfAbort(ctx context.Context, db *mysqlClient, attribute path.Path, username, userhost, filterName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	aborts, err := filterAborts(ctx, db, filterName)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to read filter definition: "+err.Error())
+		return diags
+	}
+	if !aborts {
+		return diags
+	}
+
+	currentUser, currentHost, err := queryCurrentAccount(ctx, db)
+	if err != nil {
+		addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to determine the provider account: "+err.Error())
+		return diags
+	}
+
+	applies := username == currentUser && userhost == currentHost
+	if !applies && username == defaultAccount {
+		explicit, err := hasExplicitAssignment(ctx, db, currentUser, currentHost)
+		if err != nil {
+			addMySQLError(&diags, path.Empty(), err, "Database Error", "Failed to check the provider account's filter assignment: "+err.Error())
+			return diags
+		}
+		applies = !explicit
+	}
+
+	if applies {
+		diags.AddAttributeError(attribute, "Filter Would Abort Provider Account", selfAbortDetail(currentUser, currentHost, filterName))
+	}
+	return diags
+}
+
+// effectiveFilterForAccount returns the filter that applies to an account:
+// its explicit assignment if one exists, otherwise the '%' default. An empty
+// name means the account is not audited.
+func effectiveFilterForAccount(ctx context.Context, db *mysqlClient, username, userhost string) (string, error) {
+	assignment, ok, err := effectiveAssignmentForAccount(ctx, db, username, userhost)
+	if err != nil || !ok {
+		return "", err
+	}
+	return assignment.filterName, nil
+}
+
+// effectiveAssignmentForAccount returns the mysql.audit_log_user row that
+// applies to an account, or false when the account is not audited.
+func effectiveAssignmentForAccount(ctx context.Context, db *mysqlClient, username, userhost string) (auditLogUserRow, bool, error) {
+	row := auditLogUserRow{username: username, userhost: userhost}
+	err := db.QueryRowContext(ctx,
+		"SELECT filtername FROM mysql.audit_log_user WHERE username = ? AND userhost = ?",
+		username, userhost,
+	).Scan(&row.filterName)
+	if err == nil {
+		return row, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return auditLogUserRow{}, false, err
+	}
+
+	err = db.QueryRowContext(ctx,
+		"SELECT username, userhost, filtername FROM mysql.audit_log_user WHERE username = '%' ORDER BY userhost LIMIT 1",
+	).Scan(&row.username, &row.userhost, &row.filterName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return auditLogUserRow{}, false, nil
+	}
+	if err != nil {
+		return auditLogUserRow{}, false, err
+	}
+	return row, true, nil
+}
+
+// effectiveAssignment is effectiveAssignmentForAccount over rows already read
+// from mysql.audit_log_user.
+func effectiveAssignment(users []auditLogUserRow, username, userhost string) (auditLogUserRow, bool) {
+	var fallback *auditLogUserRow
+	for i, row := range users {
+		if row.username == username && row.userhost == userhost {
+			return row, true
+		}
+		if row.username == defaultAccount && (fallback == nil || row.userhost < fallback.userhost) {
+			fallback = &users[i]
+		}
+	}
+
+	if fallback == nil {
+		return auditLogUserRow{}, false
+	}
+	return *fallback, true
+}
+
+// hasExplicitAssignment reports whether an account has its own row in
+// mysql.audit_log_user, which takes precedence over the '%' default.
+func hasExplicitAssignment(ctx context.Context, db *mysqlClient, username, userhost string) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM mysql.audit_log_user WHERE username = ? AND userhost = ?",
+		username, userhost,
+	).Scan(&count)
+	return count > 0, err
+}
+
+func selfAbortDetail(username, userhost, filterName string) string {
+	return fmt.Sprintf("Filter '%s' contains \"abort\" rules and would apply to '%s'@'%s', the account this provider "+
+		"connects as. Aborted statements could prevent the provider from running the statements needed to fix "+
+		"the filter. Set allow_self_abort = true to apply it anyway.", filterName, username, userhost)
+}
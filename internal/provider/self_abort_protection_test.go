@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSplitCurrentUser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		account      string
+		wantUsername string
+		wantUserhost string
+	}{
+		{account: "root@localhost", wantUsername: "root", wantUserhost: "localhost"},
+		{account: "terraform@%", wantUsername: "terraform", wantUserhost: "%"},
+		{account: "svc@corp@10.0.0.%", wantUsername: "svc@corp", wantUserhost: "10.0.0.%"},
+		{account: "@localhost", wantUsername: "", wantUserhost: "localhost"},
+	}
+
+	for _, tc := range tests {
+		username, userhost := splitCurrentUser(tc.account)
+		if username != tc.wantUsername || userhost != tc.wantUserhost {
+			t.Fatalf("splitCurrentUser(%q) = (%q, %q), want (%q, %q)",
+				tc.account, username, userhost, tc.wantUsername, tc.wantUserhost)
+		}
+	}
+}
+
+func TestDefinitionAborts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		definition string
+		want       bool
+	}{
+		{name: "no abort", definition: `{"filter":{"class":{"name":"connection"}}}`, want: false},
+		{name: "abort false", definition: `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":false}}}}`, want: false},
+		{name: "abort true", definition: `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`, want: true},
+		{
+			name:       "abort condition",
+			definition: `{"filter":{"class":[{"name":"connection"},{"name":"table_access","event":{"name":"delete","abort":{"field":{"name":"table_name.str","value":"t"}}}}]}}`,
+			want:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		if got := definitionAborts(tc.definition); got != tc.want {
+			t.Fatalf("%s: definitionAborts = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAssignmentOfFilterPlannedInSameRunSelfAbort(t *testing.T) {
+	t.Parallel()
+
+	abortingDefinition := `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`
+
+	tests := []struct {
+		name      string
+		username  string
+		userhost  string
+		wantError bool
+	}{
+		{name: "default_assignment", username: "%", userhost: "%", wantError: true},
+		{name: "provider_account", username: "terraform", userhost: "%", wantError: true},
+		{name: "other_account", username: "app", userhost: "%"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Neither the filter nor the assignment exists yet.
+			client := newFakeClient(&fakeServer{currentUser: "terraform@%"})
+
+			filterResp := modifyPlan(t, &AuditLogFilterResource{db: client}, map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "block_deletes"),
+				"definition": tftypes.NewValue(tftypes.String, abortingDefinition),
+			})
+			if filterResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected filter plan diagnostics: %+v", filterResp.Diagnostics)
+			}
+
+			assignmentResp := modifyPlan(t, &AuditLogUserAssignmentResource{db: client}, map[string]tftypes.Value{
+				"username":    tftypes.NewValue(tftypes.String, tc.username),
+				"userhost":    tftypes.NewValue(tftypes.String, tc.userhost),
+				"filter_name": tftypes.NewValue(tftypes.String, "block_deletes"),
+			})
+			if assignmentResp.Diagnostics.HasError() != tc.wantError {
+				t.Fatalf("assignment plan error = %t, want %t: %+v", assignmentResp.Diagnostics.HasError(), tc.wantError, assignmentResp.Diagnostics)
+			}
+			if tc.wantError && assignmentResp.Diagnostics.Errors()[0].Summary() != "Filter Would Abort Provider Account" {
+				t.Fatalf("unexpected diagnostic: %+v", assignmentResp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAssignmentRevertToSelfAbort(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(&fakeServer{
+		currentUser: "terraform@%",
+		filters: map[string]string{
+			"log_all":       `{"filter":{"log":true}}`,
+			"block_deletes": `{"filter":{"class":{"name":"table_access","event":{"name":"delete","abort":true}}}}`,
+		},
+	})
+
+	resp := modifyPlan(t, &AuditLogUserAssignmentResource{db: client}, map[string]tftypes.Value{
+		"username":    tftypes.NewValue(tftypes.String, "terraform"),
+		"userhost":    tftypes.NewValue(tftypes.String, "%"),
+		"filter_name": tftypes.NewValue(tftypes.String, "log_all"),
+		"expires_at":  tftypes.NewValue(tftypes.String, "2099-01-01T00:00:00Z"),
+		"revert_to":   tftypes.NewValue(tftypes.String, "block_deletes"),
+	})
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got: %+v", resp.Diagnostics)
+	}
+	d, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok || !d.Path().Equal(path.Root("revert_to")) || d.Summary() != "Filter Would Abort Provider Account" {
+		t.Fatalf("expected self-abort error on revert_to, got: %+v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/0ch1r/terraform-provider-auditlogfilter/internal/provider"
+)
+
+// fileProblem is a definition problem found in a specific file.
+type fileProblem struct {
+	File string `json:"file"`
+	provider.DefinitionProblem
+}
+
+func runValidateCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "output format: text, json or sarif")
+	strict := flags.Bool("strict", false, "treat lint warnings as failures")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: terraform-provider-auditlogfilters validate [-format text|json|sarif] [-strict] PATH...\n\n"+
+			"Validates audit log filter definition files. Directories are searched recursively for *.json files.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	switch *format {
+	case "text", "json", "sarif":
+	default:
+		_, _ = fmt.Fprintf(stderr, "unsupported format %q; use text, json or sarif\n", *format)
+		return 2
+	}
+
+	files, err := collectDefinitionFiles(flags.Args())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	var problems []fileProblem
+	for _, file := range files {
+		content, err := os.ReadFile(file) //nolint:gosec // G304: paths are supplied by the user on the command line.
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, err)
+			return 2
+		}
+		_, found := provider.CheckDefinition(content)
+		for _, problem := range found {
+			problems = append(problems, fileProblem{File: file, DefinitionProblem: problem})
+		}
+	}
+
+	switch *format {
+	case "json":
+		err = writeJSONProblems(stdout, problems)
+	case "sarif":
+		err = writeSARIFProblems(stdout, problems)
+	default:
+		writeTextProblems(stdout, files, problems)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	for _, problem := range problems {
+		if problem.Severity == provider.DefinitionSeverityError || *strict {
+			return 1
+		}
+	}
+	return 0
+}
+
+// collectDefinitionFiles expands the given paths into a sorted list of files.
+// Files are used as given; directories contribute every *.json file below them.
+func collectDefinitionFiles(paths []string) ([]string, error) {
+	seen := map[string]bool{}
+	var files []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			if !seen[path] {
+				seen[path] = true
+				files = append(files, path)
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(file), ".json") || seen[file] {
+				return nil
+			}
+			seen[file] = true
+			files = append(files, file)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func writeTextProblems(w io.Writer, files []string, problems []fileProblem) {
+	errorCount := 0
+	for _, problem := range problems {
+		location := problem.File
+		if problem.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", problem.File, problem.Line, problem.Column)
+		}
+		if problem.Severity == provider.DefinitionSeverityError {
+			errorCount++
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s: %s: %s\n", location, problem.Severity, problem.Path, problem.Message)
+	}
+	_, _ = fmt.Fprintf(w, "%d file(s) checked, %d error(s), %d warning(s)\n", len(files), errorCount, len(problems)-errorCount)
+}
+
+func writeJSONProblems(w io.Writer, problems []fileProblem) error {
+	if problems == nil {
+		problems = []fileProblem{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(problems)
+}
+
+// writeSARIFProblems writes problems as a SARIF 2.1.0 log so that code
+// scanning tools can annotate the offending files.
+func writeSARIFProblems(w io.Writer, problems []fileProblem) error {
+	results := make([]any, 0, len(problems))
+	for _, problem := range problems {
+		ruleID := "definition-invalid"
+		level := "error"
+		if problem.Severity == provider.DefinitionSeverityWarning {
+			ruleID = "definition-lint"
+			level = "warning"
+		}
+
+		physicalLocation := map[string]any{
+			"artifactLocation": map[string]any{"uri": filepath.ToSlash(problem.File)},
+		}
+		if problem.Line > 0 {
+			physicalLocation["region"] = map[string]any{
+				"startLine":   problem.Line,
+				"startColumn": problem.Column,
+			}
+		}
+
+		results = append(results, map[string]any{
+			"ruleId":  ruleID,
+			"level":   level,
+			"message": map[string]any{"text": problem.Path + ": " + problem.Message},
+			"locations": []any{map[string]any{
+				"physicalLocation": physicalLocation,
+				"logicalLocations": []any{map[string]any{"fullyQualifiedName": problem.Path}},
+			}},
+		})
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{
+				"driver": map[string]any{
+					"name":           "terraform-provider-auditlogfilters",
+					"version":        version,
+					"informationUri": "https://github.com/0ch1r/terraform-provider-auditlogfilters",
+					"rules": []any{
+						map[string]any{
+							"id":               "definition-invalid",
+							"shortDescription": map[string]any{"text": "Audit log filter definition is invalid"},
+						},
+						map[string]any{
+							"id":               "definition-lint",
+							"shortDescription": map[string]any{"text": "Audit log filter definition contains a logical mistake"},
+						},
+					},
+				},
+			},
+			"results": results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
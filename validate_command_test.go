@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDefinitionFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestRunValidateCommand(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeDefinitionFile(t, dir, "valid.json", `{"filter":{"class":{"name":"connection"}}}`)
+	writeDefinitionFile(t, dir, "nested/lint.json", `{"filter":{"class":{"name":"table_access","abort":true}}}`)
+	writeDefinitionFile(t, dir, "notes.txt", `not a definition`)
+
+	var stdout, stderr bytes.Buffer
+	if code := runCommand([]string{"validate", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0 for warnings only, got %d (stderr %q)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2 file(s) checked, 0 error(s), 1 warning(s)") {
+		t.Fatalf("unexpected text output: %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runCommand([]string{"validate", "-strict", dir}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 with -strict, got %d", code)
+	}
+
+	invalid := writeDefinitionFile(t, dir, "invalid.json", `{"class":{"name":"connection"}}`)
+	stdout.Reset()
+	if code := runCommand([]string{"validate", "-format", "json", invalid}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1 for an invalid definition, got %d", code)
+	}
+
+	var problems []fileProblem
+	if err := json.Unmarshal(stdout.Bytes(), &problems); err != nil {
+		t.Fatalf("json output is not valid JSON: %v", err)
+	}
+	if len(problems) != 1 || problems[0].File != invalid || problems[0].Path != "$" {
+		t.Fatalf("unexpected problems: %+v", problems)
+	}
+}
+
+func TestRunValidateCommandSARIF(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := writeDefinitionFile(t, dir, "broken.json", "{\n  \"filter\": \n")
+
+	var stdout, stderr bytes.Buffer
+	if code := runCommand([]string{"validate", "-format", "sarif", file}, &stdout, &stderr); code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("sarif output is not valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected sarif log: %+v", log)
+	}
+	if log.Runs[0].Results[0].RuleID != "definition-invalid" || log.Runs[0].Results[0].Level != "error" {
+		t.Fatalf("unexpected sarif result: %+v", log.Runs[0].Results[0])
+	}
+}
+
+func TestRunValidateCommandUsage(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := runCommand([]string{"validate"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 without paths, got %d", code)
+	}
+	if code := runCommand([]string{"validate", "-format", "xml", "x.json"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("expected exit code 2 for an unsupported format, got %d", code)
+	}
+}